@@ -1,10 +1,14 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/whywaita/pokernow2gw/pkg/pokernow2gw"
@@ -21,11 +25,11 @@ func isStdinPiped() bool {
 
 func main() {
 	// Define flags
-	input := flag.String("input", "", "Input CSV file (optional, stdin if not specified)")
-	inputShort := flag.String("i", "", "Input CSV file (shorthand)")
+	input := flag.String("input", "", "Input file: PokerNow CSV, OHH JSON/JSONL or PokerStars text (optional, stdin if not specified)")
+	inputShort := flag.String("i", "", "Input file (shorthand)")
 	output := flag.String("output", "", "Output file (optional, stdout if not specified)")
 	outputShort := flag.String("o", "", "Output file (shorthand)")
-	heroName := flag.String("hero-name", "", "Hero display name (required)")
+	heroName := flag.String("hero-name", "", "Hero display name (optional, detected from the log if not specified)")
 	timezone := flag.String("timezone", "UTC", "Timezone for output (e.g., UTC, Asia/Tokyo)")
 	tournamentName := flag.String("tournament-name", "", "Tournament name (optional)")
 	filterHU := flag.Bool("filter-hu", false, "Include heads-up hands (2 players)")
@@ -35,6 +39,17 @@ func main() {
 	rakePercent := flag.Float64("rake-percent", 0.0, "Rake percentage for cash games (e.g., 5.0 for 5%)")
 	rakeCapBB := flag.Float64("rake-cap-bb", 0.0, "Rake cap in big blinds (e.g., 4.0 for 4BB)")
 	cash := flag.Bool("cash", false, "Output in cash game format (default: tournament)")
+	inputFormat := flag.String("input-format", "", fmt.Sprintf("Input format, one of: %s (default: detected from the input)", readerNames()))
+	outputFormat := flag.String("output-format", string(pokernow2gw.DefaultOutputFormat), fmt.Sprintf("Output format, one of: %s", formatterNames()))
+	playerNames := flag.String("player-names", string(pokernow2gw.PlayerNamingFirst), "How to name players who share a nickname or rename: first (first name used), last (last name used) or id (PokerNow player ID)")
+	aliases := flag.String("aliases", "", "JSON file mapping PokerNow player IDs or display names to canonical player names (optional)")
+	anonymize := flag.String("anonymize", "", "Replace opponent names with pseudonyms for sharing: sequential (Player1, Player2, ...) or hash (seeded hash of the player ID) (optional)")
+	anonymizeSeed := flag.String("anonymize-seed", "", "Seed for --anonymize hash pseudonyms and --scrub-table hand IDs; keep it secret to stop names from being guessed")
+	scrubTable := flag.Bool("scrub-table", false, "Remove table names and replace hand IDs with seeded hashes")
+	strictValidation := flag.Bool("strict-validation", false, "Skip hands whose chips do not add up instead of keeping them with a warning")
+	strict := flag.Bool("strict", false, "Fail when the log has entries inside hands that are not recognized")
+	verbose := flag.Bool("v", false, "Print a warning for every change that loses information from the input (renumbered seats, hashed hand IDs, ...)")
+	ledger := flag.String("ledger", "", "Write the session ledger (buy-ins, rebuys, top-ups, cash-outs) to this file (.json for JSON, CSV otherwise)")
 
 	flag.Parse()
 
@@ -46,18 +61,41 @@ func main() {
 		*output = *outputShort
 	}
 
-	// Validate required flags
-	if *heroName == "" {
-		fmt.Fprintf(os.Stderr, "Error: --hero-name is required\n")
-		flag.Usage()
-		os.Exit(1)
+	// Validate input format
+	if *inputFormat != "" {
+		if _, err := pokernow2gw.LookupReader(pokernow2gw.InputFormat(*inputFormat)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitBadFlags)
+		}
+	}
+
+	// Validate player naming
+	if !slices.Contains(pokernow2gw.PlayerNamings(), pokernow2gw.PlayerNaming(*playerNames)) {
+		fmt.Fprintf(os.Stderr, "Error: unknown player naming %q (supported: first, last, id)\n", *playerNames)
+		os.Exit(exitBadFlags)
+	}
+
+	// Validate anonymization
+	if *anonymize != "" && !slices.Contains(pokernow2gw.Anonymizations(), pokernow2gw.Anonymization(*anonymize)) {
+		fmt.Fprintf(os.Stderr, "Error: unknown anonymization %q (supported: sequential, hash)\n", *anonymize)
+		os.Exit(exitBadFlags)
+	}
+	if pokernow2gw.Anonymization(*anonymize) == pokernow2gw.AnonymizeHash && *anonymizeSeed == "" {
+		fmt.Fprintln(os.Stderr, "Error: --anonymize hash needs --anonymize-seed")
+		os.Exit(exitBadFlags)
+	}
+
+	// Validate output format
+	if _, err := pokernow2gw.LookupFormatter(pokernow2gw.OutputFormat(*outputFormat)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitBadFlags)
 	}
 
 	// Parse timezone
 	loc, err := time.LoadLocation(*timezone)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: invalid timezone %q: %v\n", *timezone, err)
-		os.Exit(1)
+		os.Exit(exitBadFlags)
 	}
 
 	// Build player count filter
@@ -79,6 +117,16 @@ func main() {
 		}
 	}
 
+	// Load player aliases
+	var playerAliases map[string]string
+	if *aliases != "" {
+		playerAliases, err = readPlayerAliasesFile(*aliases)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Determine input source
 	var inputReader io.ReadCloser
 	stdinPiped := isStdinPiped()
@@ -100,7 +148,7 @@ func main() {
 		// No input source
 		fmt.Fprintf(os.Stderr, "Error: no input specified. Provide --input (-i) or pipe data to stdin\n")
 		flag.Usage()
-		os.Exit(1)
+		os.Exit(exitBadFlags)
 	}
 
 	// Determine game type
@@ -119,12 +167,37 @@ func main() {
 		RakePercent:       *rakePercent,
 		RakeCapBB:         *rakeCapBB,
 		GameType:          gameType,
+		InputFormat:       pokernow2gw.InputFormat(*inputFormat),
+		OutputFormat:      pokernow2gw.OutputFormat(*outputFormat),
+		PlayerNaming:      pokernow2gw.PlayerNaming(*playerNames),
+		PlayerAliases:     playerAliases,
+		Anonymize:         pokernow2gw.Anonymization(*anonymize),
+		AnonymizeSeed:     *anonymizeSeed,
+		ScrubTableInfo:    *scrubTable,
+		StrictValidation:  *strictValidation,
 	}
 
 	result, err := pokernow2gw.Parse(inputReader, opts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: conversion failed: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+
+	if *heroName == "" {
+		if result.HeroName != "" {
+			fmt.Fprintf(os.Stderr, "Detected hero: %s\n", result.HeroName)
+		} else {
+			fmt.Fprintln(os.Stderr, "No hero was detected in the input")
+		}
+	}
+
+	// Print unrecognized log entries to stderr
+	if len(result.Diagnostics) > 0 {
+		printDiagnostics(result.Diagnostics)
+		if *strict {
+			fmt.Fprintln(os.Stderr, "Error: the log has unrecognized entries (--strict)")
+			os.Exit(1)
+		}
 	}
 
 	// Write output
@@ -140,8 +213,155 @@ func main() {
 		}
 	}
 
+	// Write session ledger
+	if *ledger != "" {
+		if err := writeLedger(*ledger, result.Ledger); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to write ledger file %q: %v\n", *ledger, err)
+			os.Exit(1)
+		}
+	}
+
 	// Print skipped hands to stderr
 	if result.SkippedHands > 0 {
-		fmt.Fprintf(os.Stderr, "%d hands were skipped due to parse errors.\n", result.SkippedHands)
+		fmt.Fprintf(os.Stderr, "%d hands were skipped (%s).\n", result.SkippedHands, skipReasons(result))
+	}
+	mismatches := 0
+	for _, w := range result.Warnings {
+		if w.Code == pokernow2gw.WarningChipMismatch {
+			mismatches++
+		}
+	}
+	if mismatches > 0 {
+		fmt.Fprintf(os.Stderr, "%d hands do not add up but were kept (use --strict-validation to skip them).\n", mismatches)
+	}
+	if *verbose {
+		printWarnings(result.Warnings)
+	}
+}
+
+// skipReasons returns the number of skipped hands for each reason, e.g. "chip_mismatch: 2, incomplete_hand: 1"
+// Hands skipped without details (spectator hands in JSONL) are counted as "other".
+func skipReasons(result *pokernow2gw.ConvertResult) string {
+	counts := make(map[pokernow2gw.SkipReason]int)
+	for _, info := range result.SkippedHandsInfo {
+		counts[info.Reason]++
+	}
+	if other := result.SkippedHands - len(result.SkippedHandsInfo); other > 0 {
+		counts["other"] += other
+	}
+
+	reasons := make([]string, 0, len(counts))
+	for reason, count := range counts {
+		reasons = append(reasons, fmt.Sprintf("%s: %d", reason, count))
+	}
+	slices.Sort(reasons)
+	return strings.Join(reasons, ", ")
+}
+
+// printWarnings prints every warning to stderr
+func printWarnings(warnings []pokernow2gw.Warning) {
+	for _, w := range warnings {
+		if w.HandID == "" {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", w.Code, w.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: hand %s: %s: %s\n", w.HandID, w.Code, w.Message)
+		}
+	}
+}
+
+// exitBadFlags is the exit code for flags that are missing or have invalid values, as the flag package uses
+const exitBadFlags = 2
+
+// parseErrorExitCodes are the exit codes for each kind of input error
+// 1 is used for every other error and exitBadFlags for bad flags.
+var parseErrorExitCodes = map[pokernow2gw.ParseErrorKind]int{
+	pokernow2gw.ParseErrorHeader:          3,
+	pokernow2gw.ParseErrorRow:             4,
+	pokernow2gw.ParseErrorTimestamp:       5,
+	pokernow2gw.ParseErrorAmount:          6,
+	pokernow2gw.ParseErrorJSON:            7,
+	pokernow2gw.ParseErrorUnsupportedGame: 8,
+	pokernow2gw.ParseErrorInvalidHand:     9,
+}
+
+// exitCode returns the exit code for a conversion error
+func exitCode(err error) int {
+	var parseErr *pokernow2gw.ParseError
+	if errors.As(err, &parseErr) {
+		if code, ok := parseErrorExitCodes[parseErr.Kind]; ok {
+			return code
+		}
+	}
+	return 1
+}
+
+// maxDiagnosticsShown is the number of unrecognized log entries printed before the rest are summarized
+const maxDiagnosticsShown = 10
+
+// printDiagnostics prints a summary of the unrecognized log entries to stderr
+func printDiagnostics(diagnostics []pokernow2gw.Diagnostic) {
+	fmt.Fprintf(os.Stderr, "%d log entries were not recognized and were ignored:\n", len(diagnostics))
+	for i, d := range diagnostics {
+		if i == maxDiagnosticsShown {
+			fmt.Fprintf(os.Stderr, "  ... and %d more\n", len(diagnostics)-i)
+			break
+		}
+		fmt.Fprintf(os.Stderr, "  hand #%s (order %d): %s\n", d.HandNumber, d.Order, d.Entry)
+	}
+}
+
+// writeLedger writes the session ledger to path, as JSON if the extension is .json and CSV otherwise
+func writeLedger(path string, ledger *pokernow2gw.Ledger) error {
+	if ledger == nil {
+		return fmt.Errorf("the input has no session events (ledger is only available for PokerNow CSV logs)")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = ledger.WriteJSON(file)
+	} else {
+		err = ledger.WriteCSV(file)
+	}
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// readPlayerAliasesFile reads the player alias JSON file at path
+func readPlayerAliasesFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open aliases file %q: %w", path, err)
+	}
+	defer file.Close()
+
+	aliases, err := pokernow2gw.ReadPlayerAliases(file)
+	if err != nil {
+		return nil, fmt.Errorf("aliases file %q: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// formatterNames returns the registered output formats as a comma separated list
+func formatterNames() string {
+	var names []string
+	for _, name := range pokernow2gw.Formatters() {
+		names = append(names, string(name))
+	}
+	return strings.Join(names, ", ")
+}
+
+// readerNames returns the registered input formats as a comma separated list
+func readerNames() string {
+	var names []string
+	for _, name := range pokernow2gw.Readers() {
+		names = append(names, string(name))
 	}
+	return strings.Join(names, ", ")
 }
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestBadFlagsExitCode runs the CLI in a child process, since main exits
+// The child is this test binary, which runs main with the flags in POKERNOW2GW_TEST_ARGS.
+func TestBadFlagsExitCode(t *testing.T) {
+	if args, ok := os.LookupEnv("POKERNOW2GW_TEST_ARGS"); ok {
+		os.Args = append([]string{"pokernow2gw"}, strings.Split(args, "\n")...)
+		flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+		main()
+		return
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown input format", args: []string{"--input-format", "bogus"}},
+		{name: "unknown player naming", args: []string{"--player-names", "nickname"}},
+		{name: "unknown anonymization", args: []string{"--anonymize", "rot13"}},
+		{name: "hash anonymization without a seed", args: []string{"--anonymize", "hash"}},
+		{name: "unknown output format", args: []string{"--output-format", "bogus"}},
+		{name: "invalid timezone", args: []string{"--timezone", "Nowhere/Bogus"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^TestBadFlagsExitCode$")
+			cmd.Env = append(os.Environ(), "POKERNOW2GW_TEST_ARGS="+strings.Join(tt.args, "\n"))
+			out, err := cmd.CombinedOutput()
+
+			var exitErr *exec.ExitError
+			if !errors.As(err, &exitErr) || exitErr.ExitCode() != exitBadFlags {
+				t.Errorf("pokernow2gw %s: err = %v, want exit code %d\n%s", strings.Join(tt.args, " "), err, exitBadFlags, out)
+			}
+		})
+	}
+}
@@ -53,14 +53,31 @@ var lastResult []byte // Keep reference to prevent GC
 //lint:ignore U1000 This variable is used by WASM exported functions to store skipped hands detail JSON
 var lastSkippedDetail []byte // Keep reference to prevent GC
 
+// skippedDetailVersion is the version of the skipped detail JSON
+// Version 1 was a bare array of skipped hands. Version 2 is a skippedDetail object, which also has the
+// unrecognized log entries and the warnings; JavaScript tells the two apart by the version field.
+const skippedDetailVersion = 2
+
+// skippedDetail is the JSON passed to JavaScript with the skipped hands, the unrecognized log entries
+// and the warnings
+type skippedDetail struct {
+	Version      int                           `json:"version"`
+	SkippedHands []pokernow2gw.SkippedHandInfo `json:"skipped_hands"`
+	Diagnostics  []pokernow2gw.Diagnostic      `json:"diagnostics"`
+	Warnings     []pokernow2gw.Warning         `json:"warnings"`
+}
+
 //lint:ignore U1000 This variable is used by WASM exported functions to store result info
 var lastResultInfo [20]byte // Store result info: [ptr(4), len(4), skippedHands(4), skippedDetailPtr(4), skippedDetailLen(4)]
 
 //lint:ignore U1000 This function is exported to WASM and called from JavaScript
 //go:wasmexport parseCSV
-func parseCSV(csvPtr, csvLen, heroPtr, heroLen, filterFlags, gameType uint32, rakePercent, rakeCapBB float32) uint32 {
+func parseCSV(csvPtr, csvLen, heroPtr, heroLen, filterFlags, gameType uint32, rakePercent, rakeCapBB float32, formatPtr, formatLen uint32) uint32 {
 	csvText := getString(csvPtr, csvLen)
+	// An empty hero name lets the converter detect the hero from the log
 	heroName := getString(heroPtr, heroLen)
+	// Output format is a registered formatter name; empty selects the default (PokerStars)
+	outputFormat := getString(formatPtr, formatLen)
 
 	if csvText == "" {
 		errMsg := "CSV text is empty"
@@ -71,14 +88,6 @@ func parseCSV(csvPtr, csvLen, heroPtr, heroLen, filterFlags, gameType uint32, ra
 		return uint32(uintptr(unsafe.Pointer(&lastResultInfo[0])))
 	}
 
-	if heroName == "" {
-		errMsg := "Hero name is required"
-		lastResult = []byte(errMsg)
-		lastSkippedDetail = nil
-		writeResultInfo(uint32(uintptr(unsafe.Pointer(&lastResult[0]))), uint32(len(lastResult)), 0, 1, 0, 0)
-		return uint32(uintptr(unsafe.Pointer(&lastResultInfo[0])))
-	}
-
 	// Build player count filter from flags
 	// filterFlags is a bitmask: bit 0 = HU, bit 1 = SpinAndGo, bit 2 = MTT
 	var playerCountFilter pokernow2gw.PlayerCountFilter
@@ -106,6 +115,7 @@ func parseCSV(csvPtr, csvLen, heroPtr, heroLen, filterFlags, gameType uint32, ra
 		RakePercent:       float64(rakePercent),
 		RakeCapBB:         float64(rakeCapBB),
 		GameType:          gt,
+		OutputFormat:      pokernow2gw.OutputFormat(outputFormat),
 	}
 
 	result, err := pokernow2gw.Parse(reader, opts)
@@ -119,10 +129,10 @@ func parseCSV(csvPtr, csvLen, heroPtr, heroLen, filterFlags, gameType uint32, ra
 
 	lastResult = result.HH
 
-	// Encode skipped hands info as JSON
+	// Encode skipped hands info, unrecognized log entries and warnings as JSON
 	var skippedDetailPtr, skippedDetailLen uint32
-	if len(result.SkippedHandsInfo) > 0 {
-		jsonData, err := json.Marshal(result.SkippedHandsInfo)
+	if len(result.SkippedHandsInfo) > 0 || len(result.Diagnostics) > 0 || len(result.Warnings) > 0 {
+		jsonData, err := json.Marshal(skippedDetail{Version: skippedDetailVersion, SkippedHands: result.SkippedHandsInfo, Diagnostics: result.Diagnostics, Warnings: result.Warnings})
 		if err == nil {
 			lastSkippedDetail = jsonData
 			skippedDetailPtr = uint32(uintptr(unsafe.Pointer(&lastSkippedDetail[0])))
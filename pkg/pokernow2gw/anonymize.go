@@ -0,0 +1,248 @@
+package pokernow2gw
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Anonymization selects how opponents are renamed for sharing hand histories
+// The hero keeps their name. Pseudonyms replace the display names and raw names of opponents, and
+// PokerNow player IDs are dropped, so they work the same in every output format.
+type Anonymization string
+
+const (
+	// AnonymizeSequential names opponents Player1, Player2, ... in order of first appearance
+	AnonymizeSequential Anonymization = "sequential"
+	// AnonymizeHash names opponents "Player" followed by a seeded hash of their player ID
+	// The same seed gives a player the same pseudonym in every session, so stats still accumulate.
+	AnonymizeHash Anonymization = "hash"
+)
+
+// Anonymizations returns the supported anonymization modes
+func Anonymizations() []Anonymization {
+	return []Anonymization{AnonymizeSequential, AnonymizeHash}
+}
+
+// anonymizer assigns the pseudonyms of players, by Player.key
+type anonymizer struct {
+	mode       Anonymization
+	seed       string
+	pseudonyms map[string]string
+	taken      map[string]bool
+	next       int
+	names      map[string]string // 入力中の名前・プレイヤーID → 仮名
+	pattern    *regexp.Regexp    // names のいずれか（長い順）
+}
+
+// reQuotedPlayer matches a PokerNow player written as "name @ id" in a log entry
+var reQuotedPlayer = regexp.MustCompile(`"([^"]+ @ [^"]+)"`)
+
+// pseudonym returns the pseudonym of the player with key, assigning the next one to a new player
+func (a *anonymizer) pseudonym(key string) string {
+	if name, ok := a.pseudonyms[key]; ok {
+		return name
+	}
+	var name string
+	switch a.mode {
+	case AnonymizeSequential:
+		name = "Player" + strconv.Itoa(a.next)
+		a.next++
+		for a.taken[name] {
+			name = "Player" + strconv.Itoa(a.next)
+			a.next++
+		}
+	case AnonymizeHash:
+		sum := sha256.Sum256([]byte(a.seed + "\x00" + key))
+		base := "Player" + hex.EncodeToString(sum[:4])
+		name = base
+		for n := 2; a.taken[name]; n++ {
+			name = base + "_" + strconv.Itoa(n)
+		}
+	}
+	a.taken[name] = true
+	a.pseudonyms[key] = name
+	return name
+}
+
+// addName records a name or player ID of the player with key, so it is replaced in free text
+func (a *anonymizer) addName(name, key string) {
+	if name == "" {
+		return
+	}
+	if _, ok := a.names[name]; !ok {
+		a.names[name] = a.pseudonym(key)
+	}
+}
+
+// scrub replaces the player names and IDs in text with their pseudonyms
+// Names are replaced only as whole words. Players written as "name @ id" are replaced even if they
+// are not seated in any converted hand, as in the log entries of skipped hands.
+func (a *anonymizer) scrub(text string) string {
+	if a.pattern == nil && len(a.names) > 0 {
+		names := make([]string, 0, len(a.names))
+		for name := range a.names {
+			names = append(names, name)
+		}
+		slices.SortFunc(names, func(x, y string) int { return len(y) - len(x) })
+		for i, name := range names {
+			names[i] = regexp.QuoteMeta(name)
+		}
+		a.pattern = regexp.MustCompile(strings.Join(names, "|"))
+	}
+
+	if a.pattern != nil {
+		var b strings.Builder
+		last := 0
+		for _, m := range a.pattern.FindAllStringIndex(text, -1) {
+			if !isWordBoundary(text, m[0], m[1]) {
+				continue
+			}
+			b.WriteString(text[last:m[0]])
+			b.WriteString(a.names[text[m[0]:m[1]]])
+			last = m[1]
+		}
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+
+	return reQuotedPlayer.ReplaceAllStringFunc(text, func(quoted string) string {
+		return `"` + a.pseudonym(extractPlayerID(quoted[1:len(quoted)-1])) + `"`
+	})
+}
+
+// isWordBoundary reports whether text[start:end] is not part of a longer word
+func isWordBoundary(text string, start, end int) bool {
+	isWord := func(r rune) bool { return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+	if before, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isWord(before) {
+		return false
+	}
+	if after, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWord(after) {
+		return false
+	}
+	return true
+}
+
+// anonymizeHands replaces every player name except the hero's with a pseudonym and drops the player IDs
+// The hero is the player with heroKey (see Player.key), written as heroName.
+// It also renames the ledger (if any) so the ledger and the hands agree. The returned anonymizer
+// replaces the same players in the rest of the result (see anonymizeReport).
+func anonymizeHands(hands []Hand, ledger *Ledger, heroKey, heroName string, mode Anonymization, seed string) (*anonymizer, error) {
+	if !slices.Contains(Anonymizations(), mode) {
+		return nil, fmt.Errorf("unknown anonymization %q (supported: sequential, hash)", mode)
+	}
+	// Without a secret seed, anyone could hash player IDs to find who is behind a pseudonym
+	if mode == AnonymizeHash && seed == "" {
+		return nil, fmt.Errorf("hash anonymization needs a seed")
+	}
+
+	a := &anonymizer{
+		mode:       mode,
+		seed:       seed,
+		pseudonyms: make(map[string]string),
+		taken:      map[string]bool{heroName: true},
+		next:       1,
+		names:      make(map[string]string),
+	}
+	// The hero keeps their name but not their PokerNow player ID
+	if heroKey != "" {
+		a.pseudonyms[heroKey] = heroName
+	}
+
+	// Pseudonyms are assigned in order of first appearance; every name the player was known by is
+	// recorded: the logged name ("name @ id"), the nickname in it, the output name and the player ID
+	for _, hand := range hands {
+		for _, player := range hand.Players {
+			key := player.key()
+			a.addName(player.Name, key)
+			if nickname, _, ok := strings.Cut(player.Name, " @ "); ok {
+				a.addName(nickname, key)
+			}
+			a.addName(player.DisplayName, key)
+			a.addName(player.ID, key)
+		}
+	}
+	if ledger != nil {
+		for _, p := range ledger.Players {
+			a.addName(p.PlayerName, p.PlayerID)
+			a.addName(p.PlayerID, p.PlayerID)
+		}
+	}
+
+	for i := range hands {
+		hand := &hands[i]
+		for j, player := range hand.Players {
+			name := a.pseudonym(player.key())
+			hand.Players[j] = Player{SeatNumber: player.SeatNumber, Name: name, DisplayName: name, Stack: player.Stack}
+		}
+		for j, action := range hand.Actions {
+			hand.Actions[j].Player, hand.Actions[j].PlayerID = a.pseudonym(action.playerKey()), ""
+		}
+		for j, winner := range hand.Winners {
+			hand.Winners[j].Player, hand.Winners[j].PlayerID = a.pseudonym(winner.playerKey()), ""
+		}
+		if hand.dealerKey() != "" {
+			hand.Dealer, hand.DealerID = a.pseudonym(hand.dealerKey()), ""
+		}
+	}
+
+	if ledger != nil {
+		for i, p := range ledger.Players {
+			name := a.pseudonym(p.PlayerID)
+			ledger.Players[i].PlayerID, ledger.Players[i].PlayerName = name, name
+		}
+		for i, e := range ledger.Events {
+			name := a.pseudonym(e.PlayerID)
+			ledger.Events[i].PlayerID, ledger.Events[i].PlayerName = name, name
+		}
+	}
+	return a, nil
+}
+
+// anonymizeReport replaces the players in the skipped hands, unrecognized log entries and warnings
+// The raw input of skipped hands is dropped: it may name players who are not seated in any
+// converted hand, and other inputs than PokerNow CSV do not mark where the names are.
+func (a *anonymizer) anonymizeReport(result *ReadResult, warnings []Warning) {
+	for i := range result.SkippedHandsInfo {
+		info := &result.SkippedHandsInfo[i]
+		info.Detail = a.scrub(info.Detail)
+		info.RawInput = nil
+	}
+	for i := range result.Diagnostics {
+		result.Diagnostics[i].Entry = a.scrub(result.Diagnostics[i].Entry)
+	}
+	for i := range warnings {
+		warnings[i].Message = a.scrub(warnings[i].Message)
+	}
+}
+
+// scrubTableInfo removes the table names and replaces the hand IDs with seeded hashes of them
+// The new IDs are still numeric and unique, but cannot be traced back to the PokerNow game.
+// The warnings are moved to the new IDs; hand_id_hashed warnings are dropped as they name the original ID.
+func scrubTableInfo(hands []Hand, warnings []Warning, seed string) []Warning {
+	scrubbed := func(handID string) string {
+		return convertHandIDToNumeric("scrubbed:" + seed + ":" + handID)
+	}
+	for i := range hands {
+		hands[i].TableName = ""
+		hands[i].HandID = scrubbed(hands[i].HandID)
+	}
+
+	kept := warnings[:0]
+	for _, w := range warnings {
+		if w.Code == WarningHandIDHashed {
+			continue
+		}
+		if w.HandID != "" {
+			w.HandID = scrubbed(w.HandID)
+		}
+		kept = append(kept, w)
+	}
+	return kept
+}
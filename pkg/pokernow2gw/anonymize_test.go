@@ -0,0 +1,247 @@
+package pokernow2gw
+
+import (
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func anonymizeTestHands() []Hand {
+	return []Hand{
+		{
+			HandID:   "1",
+			Dealer:   "bob",
+			DealerID: "B1",
+			Players: []Player{
+				{SeatNumber: 1, Name: "bob @ B1", DisplayName: "bob", ID: "B1", Stack: 100},
+				{SeatNumber: 2, Name: "hero @ H1", DisplayName: "hero", ID: "H1", Stack: 100},
+				{SeatNumber: 3, Name: "carol @ C1", DisplayName: "carol", ID: "C1", Stack: 100},
+			},
+			Actions: []Action{
+				{Player: "bob", PlayerID: "B1", ActionType: ActionFold},
+				{Player: "hero", PlayerID: "H1", ActionType: ActionCheck},
+				{Player: "carol", PlayerID: "C1", ActionType: ActionCollect, Amount: 10},
+			},
+			Winners: []Winner{{Player: "carol", PlayerID: "C1", Amount: 10}},
+		},
+		{
+			HandID:   "2",
+			Dealer:   "hero",
+			DealerID: "H1",
+			Players: []Player{
+				{SeatNumber: 1, Name: "dave @ D1", DisplayName: "dave", ID: "D1", Stack: 100},
+				{SeatNumber: 2, Name: "hero @ H1", DisplayName: "hero", ID: "H1", Stack: 100},
+				{SeatNumber: 3, Name: "carol @ C1", DisplayName: "carol", ID: "C1", Stack: 110},
+			},
+			Actions: []Action{{Player: "dave", PlayerID: "D1", ActionType: ActionFold}},
+		},
+	}
+}
+
+func TestAnonymizeHands_Sequential(t *testing.T) {
+	hands := anonymizeTestHands()
+	ledger := &Ledger{
+		Players: []LedgerPlayer{{PlayerID: "C1", PlayerName: "carol"}, {PlayerID: "E1", PlayerName: "erin"}, {PlayerID: "H1", PlayerName: "hero"}},
+		Events:  []LedgerEvent{{PlayerID: "E1", PlayerName: "erin", Type: LedgerBuyIn}},
+	}
+	if _, err := anonymizeHands(hands, ledger, "H1", "hero", AnonymizeSequential, ""); err != nil {
+		t.Fatalf("anonymizeHands() failed: %v", err)
+	}
+
+	wantPlayers := []Player{
+		{SeatNumber: 1, Name: "Player1", DisplayName: "Player1", Stack: 100},
+		{SeatNumber: 2, Name: "hero", DisplayName: "hero", Stack: 100},
+		{SeatNumber: 3, Name: "Player2", DisplayName: "Player2", Stack: 100},
+	}
+	if diff := cmp.Diff(wantPlayers, hands[0].Players); diff != "" {
+		t.Errorf("Players mismatch (-want +got):\n%s", diff)
+	}
+	wantActions := []Action{
+		{Player: "Player1", ActionType: ActionFold},
+		{Player: "hero", ActionType: ActionCheck},
+		{Player: "Player2", ActionType: ActionCollect, Amount: 10},
+	}
+	if diff := cmp.Diff(wantActions, hands[0].Actions); diff != "" {
+		t.Errorf("Actions mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]Winner{{Player: "Player2", Amount: 10}}, hands[0].Winners); diff != "" {
+		t.Errorf("Winners mismatch (-want +got):\n%s", diff)
+	}
+	if hands[0].Dealer != "Player1" || hands[1].Dealer != "hero" {
+		t.Errorf("Dealers = %q, %q, want Player1, hero", hands[0].Dealer, hands[1].Dealer)
+	}
+	// Pseudonyms are stable across hands
+	if got := hands[1].Players[0].DisplayName + "," + hands[1].Players[2].DisplayName; got != "Player3,Player2" {
+		t.Errorf("second hand opponents = %s, want Player3,Player2", got)
+	}
+
+	wantLedger := &Ledger{
+		Players: []LedgerPlayer{{PlayerID: "Player2", PlayerName: "Player2"}, {PlayerID: "Player4", PlayerName: "Player4"}, {PlayerID: "hero", PlayerName: "hero"}},
+		Events:  []LedgerEvent{{PlayerID: "Player4", PlayerName: "Player4", Type: LedgerBuyIn}},
+	}
+	if diff := cmp.Diff(wantLedger, ledger); diff != "" {
+		t.Errorf("Ledger mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAnonymizeHands_Hash(t *testing.T) {
+	pseudonym := func(seed string) string {
+		hands := anonymizeTestHands()
+		if _, err := anonymizeHands(hands, nil, "H1", "hero", AnonymizeHash, seed); err != nil {
+			t.Fatalf("anonymizeHands() failed: %v", err)
+		}
+		return hands[0].Players[0].DisplayName
+	}
+
+	got := pseudonym("study-group")
+	if !regexp.MustCompile(`^Player[0-9a-f]{8}$`).MatchString(got) {
+		t.Errorf("pseudonym = %q, want Player followed by 8 hex digits", got)
+	}
+	if again := pseudonym("study-group"); again != got {
+		t.Errorf("pseudonym with the same seed = %q, want %q", again, got)
+	}
+	if other := pseudonym("another-seed"); other == got {
+		t.Errorf("pseudonym with another seed should differ, got %q for both", got)
+	}
+}
+
+func TestAnonymizeHands_PseudonymIsNotTheHeroName(t *testing.T) {
+	hands := []Hand{{Players: []Player{
+		{Name: "Player1", DisplayName: "Player1"},
+		{Name: "bob", DisplayName: "bob"},
+	}}}
+	if _, err := anonymizeHands(hands, nil, "Player1", "Player1", AnonymizeSequential, ""); err != nil {
+		t.Fatalf("anonymizeHands() failed: %v", err)
+	}
+	if got := hands[0].Players[1].DisplayName; got != "Player2" {
+		t.Errorf("opponent = %q, want Player2", got)
+	}
+}
+
+func TestAnonymizeHands_HashNeedsASeed(t *testing.T) {
+	if _, err := anonymizeHands(anonymizeTestHands(), nil, "H1", "hero", AnonymizeHash, ""); err == nil {
+		t.Error("anonymizeHands() should fail for hash pseudonyms without a seed")
+	}
+}
+
+func TestAnonymizer_Scrub(t *testing.T) {
+	hands := anonymizeTestHands()
+	hands[0].Players[0].Name = "bob b @ B1"
+	a, err := anonymizeHands(hands, nil, "H1", "hero", AnonymizeSequential, "")
+	if err != nil {
+		t.Fatalf("anonymizeHands() failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "logged name", text: `"bob b @ B1" folds`, want: `"Player1" folds`},
+		{name: "nickname and output name", text: "bob b is bob", want: "Player1 is Player1"},
+		{name: "player ID", text: "player B1 and C1", want: "player Player1 and Player2"},
+		{name: "hero keeps their name but not their ID", text: `"hero @ H1" calls 20`, want: `"hero" calls 20`},
+		{name: "player not in any hand", text: `"erin @ E1" shows a 7♣, 2♦.`, want: `"Player4" shows a 7♣, 2♦.`},
+		{name: "whole words only", text: "bobby and carolina", want: "bobby and carolina"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.scrub(tt.text); got != tt.want {
+				t.Errorf("scrub(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_AnonymizeReport(t *testing.T) {
+	// Hand #1 is never closed and is skipped; hand #2 has an entry the parser does not know
+	csv := `entry,at,order
+"-- ending hand #2 --",2025-11-15T05:10:14.567Z,19
+"""alice @ A1"" collected 20 from pot",2025-11-15T05:10:14.567Z,18
+"Uncalled bet of 10 returned to ""alice @ A1""",2025-11-15T05:10:14.567Z,17
+"""bob @ B1"" folds",2025-11-15T05:10:14.567Z,16
+"""bob @ B1"" waves at ""zed @ Z9""",2025-11-15T05:10:14.567Z,15
+"""alice @ A1"" posts a big blind of 20",2025-11-15T05:10:14.567Z,14
+"""bob @ B1"" posts a small blind of 10",2025-11-15T05:10:14.567Z,13
+"Your hand is Q♠, Q♥",2025-11-15T05:10:14.567Z,12
+"Player stacks: #1 ""bob @ B1"" (1000) | #2 ""alice @ A1"" (1000)",2025-11-15T05:10:14.567Z,11
+"-- starting hand #2 (id: test124) (No Limit Texas Hold'em) (dealer: ""bob @ B1"") --",2025-11-15T05:10:14.567Z,10
+"""zed @ Z9"" posts a big blind of 20",2025-11-15T05:09:14.567Z,3
+"Player stacks: #1 ""zed @ Z9"" (1000) | #2 ""alice @ A1"" (1000)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""alice @ A1"") --",2025-11-15T05:09:14.567Z,1`
+
+	result, err := ParseCSV(strings.NewReader(csv), ConvertOptions{
+		HeroName:     "alice",
+		TimeLocation: time.UTC,
+		Anonymize:    AnonymizeSequential,
+	})
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+	if len(result.SkippedHandsInfo) != 1 || len(result.Diagnostics) != 1 {
+		t.Fatalf("got %d skipped hands and %d diagnostics, want 1 and 1", len(result.SkippedHandsInfo), len(result.Diagnostics))
+	}
+	if raw := result.SkippedHandsInfo[0].RawInput; raw != nil {
+		t.Errorf("RawInput = %q, want it dropped", raw)
+	}
+	if got, want := result.Diagnostics[0].Entry, `"Player1" waves at "Player2"`; got != want {
+		t.Errorf("Diagnostics[0].Entry = %q, want %q", got, want)
+	}
+}
+
+func TestParse_Anonymize(t *testing.T) {
+	inputPath := "../../sample/input/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.csv"
+	input, err := os.ReadFile(inputPath)
+	if os.IsNotExist(err) {
+		t.Skip("Sample input file not found, skipping anonymization test")
+	}
+	if err != nil {
+		t.Fatalf("Failed to read input file: %v", err)
+	}
+
+	plain, err := Parse(strings.NewReader(string(input)), ConvertOptions{HeroName: "whywaita", TimeLocation: time.UTC})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	// Opponent names and PokerNow player IDs from the sample log, and a hand ID of the plain output
+	secrets := []string{"ANN", "piyo", "ramune", "tanaka", "wafu", "IjVUpuZ9EK", "8_zd4NO6-H", "3rSQmMhWok", "DtjzvbAuKs"}
+	secrets = append(secrets, regexp.MustCompile(`Hand #(\d+)`).FindStringSubmatch(string(plain.HH))[1])
+
+	for _, format := range Formatters() {
+		t.Run(string(format), func(t *testing.T) {
+			result, err := Parse(strings.NewReader(string(input)), ConvertOptions{
+				HeroName:       "whywaita",
+				TimeLocation:   time.UTC,
+				OutputFormat:   format,
+				Anonymize:      AnonymizeSequential,
+				ScrubTableInfo: true,
+			})
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			hh := string(result.HH)
+			for _, secret := range secrets {
+				if strings.Contains(hh, secret) {
+					t.Errorf("anonymized output contains %q", secret)
+				}
+			}
+			for _, w := range result.Warnings {
+				if !strings.Contains(hh, w.HandID) || slices.ContainsFunc(secrets, func(secret string) bool { return strings.Contains(w.Message, secret) }) {
+					t.Errorf("warning %+v is not about an anonymized hand", w)
+				}
+			}
+			if !strings.Contains(hh, "whywaita") || !strings.Contains(hh, "Player1") {
+				t.Error("anonymized output should keep the hero and name opponents Player1, Player2, ...")
+			}
+			if format == OutputFormatPokerStars && strings.Count(hh, "\n") != strings.Count(string(plain.HH), "\n") {
+				t.Error("anonymization should not change the hands apart from the names and IDs")
+			}
+		})
+	}
+}
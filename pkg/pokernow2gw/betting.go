@@ -0,0 +1,160 @@
+package pokernow2gw
+
+import (
+	"fmt"
+	"math"
+)
+
+// bettingEngine replays the betting of a hand action by action
+// It tracks the chips each player has committed on the current street, the current bet, the minimum
+// raise and the chips each player has behind, so call amounts, raise increments and all-ins can be
+// written exactly. Actions that are not legal in that state are reported as errors.
+// Amounts follow PokerNow: calls, bets, raises and blinds are street totals.
+type bettingEngine struct {
+	hand       Hand
+	street     Street
+	seated     map[string]bool
+	committed  map[string]float64 // このストリートでの各プレイヤーの投入額
+	behind     map[string]float64 // 各プレイヤーの残りスタック（スタック不明のプレイヤーは含まない）
+	currentBet float64
+	minRaise   float64 // 次のレイズで上乗せが必要な最小額
+}
+
+// bettingStep is the effect of one action on the betting
+type bettingStep struct {
+	Added   float64 // Chips put in by the action
+	RaiseBy float64 // How much a bet or raise adds to the current bet
+	To      float64 // The player's street total after the action
+	AllIn   bool    // The player has nothing behind after the action
+}
+
+// newBettingEngine returns an engine at the start of the hand
+func newBettingEngine(hand Hand) *bettingEngine {
+	e := &bettingEngine{
+		hand:      hand,
+		seated:    make(map[string]bool, len(hand.Players)),
+		committed: make(map[string]float64),
+		behind:    make(map[string]float64, len(hand.Players)),
+		minRaise:  hand.BigBlind,
+	}
+	for _, player := range hand.Players {
+		e.seated[player.key()] = true
+		if player.Stack > 0 {
+			e.behind[player.key()] = player.Stack
+		}
+	}
+	return e
+}
+
+// apply replays one action and returns its effect
+// Showdown actions (shows, collections) do not change the betting and return an empty step.
+func (e *bettingEngine) apply(action Action) (bettingStep, error) {
+	key := action.playerKey()
+	if !e.seated[key] {
+		return bettingStep{}, fmt.Errorf("%s acts but is not seated", action.Player)
+	}
+	if action.Street != e.street {
+		e.street = action.Street
+		e.committed = make(map[string]float64)
+		e.currentBet = 0
+		e.minRaise = e.hand.BigBlind
+	}
+	street := streetName(action.Street)
+	committed := e.committed[key]
+
+	switch action.ActionType {
+	case ActionPostAnte, ActionPostDeadSB:
+		// Antes and missing small blinds are dead money and do not count toward the player's bet
+		return e.put(action, action.Amount, committed)
+
+	case ActionPostSB, ActionPostBB, ActionPostStraddle, ActionPostDeadBB:
+		to := math.Max(committed, action.Amount)
+		step, err := e.put(action, roundAmount(to-committed), to)
+		if err != nil {
+			return step, err
+		}
+		// The big blind and a straddle set the size of the first raise
+		if action.ActionType != ActionPostSB {
+			e.minRaise = math.Max(e.minRaise, to)
+		}
+		e.currentBet = math.Max(e.currentBet, to)
+		return step, nil
+
+	case ActionCall:
+		to := action.Amount
+		if to > e.currentBet+amountTolerance {
+			return bettingStep{}, fmt.Errorf("%s calls %s on the %s, but the bet is %s", action.Player, formatNumber(to), street, formatNumber(e.currentBet))
+		}
+		if to <= committed+amountTolerance {
+			return bettingStep{}, fmt.Errorf("%s calls %s on the %s, but already has %s in", action.Player, formatNumber(to), street, formatNumber(committed))
+		}
+		step, err := e.put(action, roundAmount(to-committed), to)
+		if err != nil {
+			return step, err
+		}
+		if to < e.currentBet-amountTolerance && !step.AllIn {
+			return bettingStep{}, fmt.Errorf("%s calls %s on the %s, but the bet is %s and they are not all-in", action.Player, formatNumber(to), street, formatNumber(e.currentBet))
+		}
+		return step, nil
+
+	case ActionBet, ActionRaise:
+		to := action.Amount
+		if to <= e.currentBet+amountTolerance || to <= committed+amountTolerance {
+			return bettingStep{}, fmt.Errorf("%s bets or raises to %s on the %s, but the bet is already %s", action.Player, formatNumber(to), street, formatNumber(math.Max(e.currentBet, committed)))
+		}
+		step, err := e.put(action, roundAmount(to-committed), to)
+		if err != nil {
+			return step, err
+		}
+		step.RaiseBy = roundAmount(to - e.currentBet)
+		if step.RaiseBy < e.minRaise-amountTolerance {
+			// A short all-in is allowed but does not reopen the betting
+			if !step.AllIn {
+				return bettingStep{}, fmt.Errorf("%s raises to %s on the %s, but the minimum is %s", action.Player, formatNumber(to), street, formatNumber(e.currentBet+e.minRaise))
+			}
+		} else {
+			e.minRaise = step.RaiseBy
+		}
+		e.currentBet = to
+		return step, nil
+
+	case ActionCheck:
+		if committed < e.currentBet-amountTolerance {
+			return bettingStep{}, fmt.Errorf("%s checks on the %s, but the bet is %s", action.Player, street, formatNumber(e.currentBet))
+		}
+
+	case ActionUncalled:
+		// The bet returned is the part of the largest commitment that the next largest did not match
+		called := 0.0
+		for other, amount := range e.committed {
+			if other != key {
+				called = math.Max(called, amount)
+			}
+		}
+		if uncalled := roundAmount(committed - called); math.Abs(uncalled-action.Amount) > amountTolerance {
+			return bettingStep{}, fmt.Errorf("uncalled bet of %s is returned to %s on the %s, but %s of their bet was not called", formatNumber(action.Amount), action.Player, street, formatNumber(uncalled))
+		}
+		e.committed[key] = roundAmount(committed - action.Amount)
+		e.currentBet = e.committed[key]
+		if behind, ok := e.behind[key]; ok {
+			e.behind[key] = roundAmount(behind + action.Amount)
+		}
+		return bettingStep{To: e.committed[key]}, nil
+	}
+	return bettingStep{To: committed}, nil
+}
+
+// put moves chips from a player's stack into the pot, leaving the player's street total at to
+func (e *bettingEngine) put(action Action, added, to float64) (bettingStep, error) {
+	key := action.playerKey()
+	step := bettingStep{Added: added, To: to, AllIn: action.IsAllIn}
+	if behind, ok := e.behind[key]; ok {
+		if added > behind+amountTolerance {
+			return bettingStep{}, fmt.Errorf("%s puts in %s on the %s, but only has %s behind", action.Player, formatNumber(added), streetName(action.Street), formatNumber(behind))
+		}
+		e.behind[key] = roundAmount(behind - added)
+		step.AllIn = step.AllIn || e.behind[key] <= amountTolerance
+	}
+	e.committed[key] = to
+	return step, nil
+}
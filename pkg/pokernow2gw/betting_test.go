@@ -0,0 +1,180 @@
+package pokernow2gw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBettingEngine(t *testing.T) {
+	players := []Player{
+		{SeatNumber: 1, DisplayName: "alice", Stack: 1000},
+		{SeatNumber: 2, DisplayName: "bob", Stack: 1000},
+		{SeatNumber: 3, DisplayName: "carol", Stack: 300},
+	}
+
+	tests := []struct {
+		name    string
+		actions []Action
+		want    []bettingStep
+	}{
+		{
+			name: "re-raise gives the raise increment over the current bet",
+			actions: []Action{
+				{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+				{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+				{Player: "carol", ActionType: ActionRaise, Amount: 60, Street: StreetPreflop},
+				{Player: "alice", ActionType: ActionRaise, Amount: 200, Street: StreetPreflop},
+				{Player: "bob", ActionType: ActionCall, Amount: 200, Street: StreetPreflop},
+			},
+			want: []bettingStep{
+				{Added: 10, To: 10},
+				{Added: 20, To: 20},
+				{Added: 60, RaiseBy: 40, To: 60},
+				{Added: 190, RaiseBy: 140, To: 200},
+				{Added: 180, To: 200},
+			},
+		},
+		{
+			name: "straddle sets the bet and the minimum raise",
+			actions: []Action{
+				{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+				{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+				{Player: "carol", ActionType: ActionPostStraddle, Amount: 40, Street: StreetPreflop},
+				{Player: "alice", ActionType: ActionRaise, Amount: 80, Street: StreetPreflop},
+				{Player: "bob", ActionType: ActionCall, Amount: 80, Street: StreetPreflop},
+			},
+			want: []bettingStep{
+				{Added: 10, To: 10},
+				{Added: 20, To: 20},
+				{Added: 40, To: 40},
+				{Added: 70, RaiseBy: 40, To: 80},
+				{Added: 60, To: 80},
+			},
+		},
+		{
+			name: "antes come out of the stack, so an all-in is detected without the log saying so",
+			actions: []Action{
+				{Player: "alice", ActionType: ActionPostAnte, Amount: 5, Street: StreetPreflop},
+				{Player: "bob", ActionType: ActionPostAnte, Amount: 5, Street: StreetPreflop},
+				{Player: "carol", ActionType: ActionPostAnte, Amount: 5, Street: StreetPreflop},
+				{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+				{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+				{Player: "carol", ActionType: ActionRaise, Amount: 295, Street: StreetPreflop},
+				{Player: "alice", ActionType: ActionCall, Amount: 295, Street: StreetPreflop},
+			},
+			want: []bettingStep{
+				{Added: 5},
+				{Added: 5},
+				{Added: 5},
+				{Added: 10, To: 10},
+				{Added: 20, To: 20},
+				{Added: 295, RaiseBy: 275, To: 295, AllIn: true},
+				{Added: 285, To: 295},
+			},
+		},
+		{
+			name: "short all-in raise does not reopen the minimum raise",
+			actions: []Action{
+				{Player: "alice", ActionType: ActionBet, Amount: 200, Street: StreetFlop},
+				{Player: "carol", ActionType: ActionRaise, Amount: 300, Street: StreetFlop, IsAllIn: true},
+				{Player: "bob", ActionType: ActionRaise, Amount: 500, Street: StreetFlop},
+			},
+			want: []bettingStep{
+				{Added: 200, RaiseBy: 200, To: 200},
+				{Added: 300, RaiseBy: 100, To: 300, AllIn: true},
+				{Added: 500, RaiseBy: 200, To: 500},
+			},
+		},
+		{
+			name: "short all-in call and the uncalled bet",
+			actions: []Action{
+				{Player: "bob", ActionType: ActionBet, Amount: 500, Street: StreetFlop},
+				{Player: "carol", ActionType: ActionCall, Amount: 300, Street: StreetFlop, IsAllIn: true},
+				{Player: "alice", ActionType: ActionFold, Street: StreetFlop},
+				{Player: "bob", ActionType: ActionUncalled, Amount: 200, Street: StreetFlop},
+			},
+			want: []bettingStep{
+				{Added: 500, RaiseBy: 500, To: 500},
+				{Added: 300, To: 300, AllIn: true},
+				{},
+				{To: 300},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newBettingEngine(Hand{Players: players, BigBlind: 20})
+			var got []bettingStep
+			for _, action := range tt.actions {
+				step, err := engine.apply(action)
+				if err != nil {
+					t.Fatalf("apply(%+v) failed: %v", action, err)
+				}
+				got = append(got, step)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("steps mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBettingEngine_IllegalSequences(t *testing.T) {
+	players := []Player{
+		{SeatNumber: 1, DisplayName: "alice", Stack: 1000},
+		{SeatNumber: 2, DisplayName: "bob", Stack: 100},
+	}
+	blinds := []Action{
+		{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+		{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+	}
+
+	tests := []struct {
+		name    string
+		action  Action
+		wantErr string
+	}{
+		{
+			name:    "raise below the minimum",
+			action:  Action{Player: "alice", ActionType: ActionRaise, Amount: 30, Street: StreetPreflop},
+			wantErr: "alice raises to 30 on the preflop, but the minimum is 40",
+		},
+		{
+			name:    "bet into a bet",
+			action:  Action{Player: "alice", ActionType: ActionBet, Amount: 20, Street: StreetPreflop},
+			wantErr: "alice bets or raises to 20 on the preflop, but the bet is already 20",
+		},
+		{
+			name:    "check facing a bet",
+			action:  Action{Player: "alice", ActionType: ActionCheck, Street: StreetPreflop},
+			wantErr: "alice checks on the preflop, but the bet is 20",
+		},
+		{
+			name:    "call with nothing to call",
+			action:  Action{Player: "bob", ActionType: ActionCall, Amount: 20, Street: StreetPreflop},
+			wantErr: "bob calls 20 on the preflop, but already has 20 in",
+		},
+		{
+			name:    "raise beyond the stack",
+			action:  Action{Player: "bob", ActionType: ActionRaise, Amount: 200, Street: StreetPreflop},
+			wantErr: "bob puts in 180 on the preflop, but only has 80 behind",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := newBettingEngine(Hand{Players: players, BigBlind: 20})
+			for _, action := range blinds {
+				if _, err := engine.apply(action); err != nil {
+					t.Fatalf("apply(%+v) failed: %v", action, err)
+				}
+			}
+			_, err := engine.apply(tt.action)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("apply() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,32 +1,37 @@
 package pokernow2gw
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"io"
 )
 
+// sniffSize is the number of bytes peeked at the start of the input to detect its format
+const sniffSize = 1 << 20
+
 // Parse reads input (CSV or JSON) from reader and converts to GTO Wizard HH format
-// Automatically detects the input format (CSV, OHH JSON, or JSONL)
+// The input format is taken from opts.InputFormat, or detected by asking every registered
+// Reader to sniff the start of the input (PokerNow CSV, OHH JSON and JSONL are built in).
+// Only a buffered prefix of the input is inspected, so CSV and JSONL input is streamed
 func Parse(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
-	// Read all data to detect format
-	data, err := io.ReadAll(r)
-	if err != nil {
+	br := bufio.NewReaderSize(r, sniffSize)
+	prefix, err := br.Peek(sniffSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
 		return nil, err
 	}
+	// A short read means the prefix is the whole input
+	complete := err == io.EOF
 
-	// Try to detect JSON format
-	if isJSONFormat(data) {
-		return ReadOHH(bytes.NewReader(data), opts)
+	reader, err := selectReader(opts, prefix, complete)
+	if err != nil {
+		return nil, err
 	}
-
-	// Try to detect JSONL format (JSON Lines)
-	if isJSONLFormat(data) {
-		return ReadJSONL(bytes.NewReader(data), opts)
+	result, err := reader.Read(br, opts)
+	if err != nil {
+		return nil, err
 	}
-
-	// Default to CSV format
-	return parseCSV(bytes.NewReader(data), opts)
+	return convertReadResult(result, opts)
 }
 
 // ParseCSV reads PokerNow CSV from reader and converts to GTO Wizard HH format
@@ -35,16 +40,11 @@ func ParseCSV(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
 	return Parse(r, opts)
 }
 
-// parseCSV is the internal CSV parser
-func parseCSV(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
-	// Read CSV
-	entries, err := ReadCSV(r)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert entries
-	return ConvertEntries(entries, opts)
+// readPokerNowCSV reads a PokerNow CSV log, streaming its entries straight into the hand parser
+func readPokerNowCSV(r io.Reader, opts ConvertOptions) (*ReadResult, error) {
+	return readEntryStream(func(fn func(LogEntry) error) error {
+		return StreamCSV(r, fn)
+	}, opts)
 }
 
 // isJSONFormat checks if the data is JSON format
@@ -118,22 +118,24 @@ func TestParseHands(t *testing.T) {
 					HandNumber: "1",
 					HandID:     "17066136185775469334",
 					Dealer:     "player1",
+					DealerID:   "id1",
 					StartTime:  baseTime,
 					SmallBlind: 10,
 					BigBlind:   20,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "player1 @ id1", DisplayName: "player1", Stack: 1000},
-						{SeatNumber: 2, Name: "player2 @ id2", DisplayName: "player2", Stack: 1000},
+						{SeatNumber: 1, Name: "player1 @ id1", DisplayName: "player1", ID: "id1", Stack: 1000},
+						{SeatNumber: 2, Name: "player2 @ id2", DisplayName: "player2", ID: "id2", Stack: 1000},
 					},
 					HeroCards: []string{"Ah", "Kh"},
 					Actions: []Action{
-						{Player: "player1", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
-						{Player: "player2", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
-						{Player: "player1", ActionType: ActionFold, Street: StreetPreflop},
-						{Player: "player2", ActionType: ActionCollect, Amount: 10, Street: StreetShowdown},
+						{Player: "player1", PlayerID: "id1", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+						{Player: "player2", PlayerID: "id2", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+						{Player: "player1", PlayerID: "id1", ActionType: ActionFold, Street: StreetPreflop},
+						{Player: "player2", PlayerID: "id2", ActionType: ActionCollect, Amount: 10, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "player2", Amount: 10},
+						{Player: "player2", PlayerID: "id2", Amount: 10},
 					},
 				},
 			},
@@ -160,28 +162,30 @@ func TestParseHands(t *testing.T) {
 					HandNumber: "2",
 					HandID:     "2",
 					Dealer:     "alice",
+					DealerID:   "abc",
 					StartTime:  baseTime,
 					SmallBlind: 5,
 					BigBlind:   10,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "alice @ abc", DisplayName: "alice", Stack: 500},
-						{SeatNumber: 2, Name: "bob @ def", DisplayName: "bob", Stack: 500},
+						{SeatNumber: 1, Name: "alice @ abc", DisplayName: "alice", ID: "abc", Stack: 500},
+						{SeatNumber: 2, Name: "bob @ def", DisplayName: "bob", ID: "def", Stack: 500},
 					},
 					HeroCards: []string{"Td", "Tc"},
 					Board: Board{
 						Flop: []string{"Ah", "Kd", "Qs"},
 					},
 					Actions: []Action{
-						{Player: "alice", ActionType: ActionPostSB, Amount: 5, Street: StreetPreflop},
-						{Player: "bob", ActionType: ActionPostBB, Amount: 10, Street: StreetPreflop},
-						{Player: "alice", ActionType: ActionCall, Amount: 5, Street: StreetPreflop},
-						{Player: "bob", ActionType: ActionCheck, Street: StreetPreflop},
-						{Player: "alice", ActionType: ActionBet, Amount: 20, Street: StreetFlop},
-						{Player: "bob", ActionType: ActionFold, Street: StreetFlop},
-						{Player: "alice", ActionType: ActionCollect, Amount: 20, Street: StreetShowdown},
+						{Player: "alice", PlayerID: "abc", ActionType: ActionPostSB, Amount: 5, Street: StreetPreflop},
+						{Player: "bob", PlayerID: "def", ActionType: ActionPostBB, Amount: 10, Street: StreetPreflop},
+						{Player: "alice", PlayerID: "abc", ActionType: ActionCall, Amount: 5, Street: StreetPreflop},
+						{Player: "bob", PlayerID: "def", ActionType: ActionCheck, Street: StreetPreflop},
+						{Player: "alice", PlayerID: "abc", ActionType: ActionBet, Amount: 20, Street: StreetFlop},
+						{Player: "bob", PlayerID: "def", ActionType: ActionFold, Street: StreetFlop},
+						{Player: "alice", PlayerID: "abc", ActionType: ActionCollect, Amount: 20, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "alice", Amount: 20},
+						{Player: "alice", PlayerID: "abc", Amount: 20},
 					},
 				},
 			},
@@ -218,12 +222,14 @@ func TestParseHands(t *testing.T) {
 					HandNumber: "3",
 					HandID:     "6504957911579380203",
 					Dealer:     "charlie",
+					DealerID:   "ghi",
 					StartTime:  baseTime,
 					SmallBlind: 10,
 					BigBlind:   20,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "charlie @ ghi", DisplayName: "charlie", Stack: 1000},
-						{SeatNumber: 2, Name: "dave @ jkl", DisplayName: "dave", Stack: 1000},
+						{SeatNumber: 1, Name: "charlie @ ghi", DisplayName: "charlie", ID: "ghi", Stack: 1000},
+						{SeatNumber: 2, Name: "dave @ jkl", DisplayName: "dave", ID: "jkl", Stack: 1000},
 					},
 					HeroCards: []string{"As", "7h"},
 					Board: Board{
@@ -232,25 +238,25 @@ func TestParseHands(t *testing.T) {
 						River: "6h",
 					},
 					Actions: []Action{
-						{Player: "charlie", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
-						{Player: "dave", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
-						{Player: "charlie", ActionType: ActionCall, Amount: 10, Street: StreetPreflop},
-						{Player: "dave", ActionType: ActionCheck, Street: StreetPreflop},
-						{Player: "charlie", ActionType: ActionCheck, Street: StreetFlop},
-						{Player: "dave", ActionType: ActionBet, Amount: 50, Street: StreetFlop},
-						{Player: "charlie", ActionType: ActionCall, Amount: 50, Street: StreetFlop},
-						{Player: "charlie", ActionType: ActionCheck, Street: StreetTurn},
-						{Player: "dave", ActionType: ActionCheck, Street: StreetTurn},
-						{Player: "charlie", ActionType: ActionBet, Amount: 100, Street: StreetRiver},
-						{Player: "dave", ActionType: ActionCall, Amount: 100, Street: StreetRiver},
-						{Player: "charlie", ActionType: ActionShow, Street: StreetShowdown},
-						{Player: "dave", ActionType: ActionShow, Street: StreetShowdown},
-						{Player: "charlie", ActionType: ActionCollect, Amount: 170, Street: StreetShowdown},
-						{Player: "dave", ActionType: ActionCollect, Amount: 170, Street: StreetShowdown},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionCall, Amount: 10, Street: StreetPreflop},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionCheck, Street: StreetPreflop},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionCheck, Street: StreetFlop},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionBet, Amount: 50, Street: StreetFlop},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionCall, Amount: 50, Street: StreetFlop},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionCheck, Street: StreetTurn},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionCheck, Street: StreetTurn},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionBet, Amount: 100, Street: StreetRiver},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionCall, Amount: 100, Street: StreetRiver},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionShow, Street: StreetShowdown},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionShow, Street: StreetShowdown},
+						{Player: "charlie", PlayerID: "ghi", ActionType: ActionCollect, Amount: 170, Street: StreetShowdown},
+						{Player: "dave", PlayerID: "jkl", ActionType: ActionCollect, Amount: 170, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "charlie", Amount: 170, HandCards: []string{"As", "7h"}},
-						{Player: "dave", Amount: 170, HandCards: []string{"Ac", "8d"}},
+						{Player: "charlie", PlayerID: "ghi", Amount: 170, HandCards: []string{"As", "7h"}},
+						{Player: "dave", PlayerID: "jkl", Amount: 170, HandCards: []string{"Ac", "8d"}},
 					},
 				},
 			},
@@ -277,29 +283,31 @@ func TestParseHands(t *testing.T) {
 					HandNumber: "4",
 					HandID:     "4",
 					Dealer:     "eve",
+					DealerID:   "mno",
 					StartTime:  baseTime,
 					SmallBlind: 10,
 					BigBlind:   20,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "eve @ mno", DisplayName: "eve", Stack: 100},
-						{SeatNumber: 2, Name: "frank @ pqr", DisplayName: "frank", Stack: 200},
+						{SeatNumber: 1, Name: "eve @ mno", DisplayName: "eve", ID: "mno", Stack: 100},
+						{SeatNumber: 2, Name: "frank @ pqr", DisplayName: "frank", ID: "pqr", Stack: 200},
 					},
 					HeroCards: []string{"Jc", "Jd"},
 					Board: Board{
 						Flop: []string{"Jh", "Qd", "Ks"},
 					},
 					Actions: []Action{
-						{Player: "eve", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
-						{Player: "frank", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
-						{Player: "eve", ActionType: ActionRaise, Amount: 100, Street: StreetPreflop, IsAllIn: true},
-						{Player: "frank", ActionType: ActionCall, Amount: 80, Street: StreetPreflop},
-						{Player: "eve", ActionType: ActionShow, Street: StreetShowdown},
-						{Player: "frank", ActionType: ActionShow, Street: StreetShowdown},
-						{Player: "frank", ActionType: ActionCollect, Amount: 200, Street: StreetShowdown},
+						{Player: "eve", PlayerID: "mno", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+						{Player: "frank", PlayerID: "pqr", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+						{Player: "eve", PlayerID: "mno", ActionType: ActionRaise, Amount: 100, Street: StreetPreflop, IsAllIn: true},
+						{Player: "frank", PlayerID: "pqr", ActionType: ActionCall, Amount: 80, Street: StreetPreflop},
+						{Player: "eve", PlayerID: "mno", ActionType: ActionShow, Street: StreetShowdown},
+						{Player: "frank", PlayerID: "pqr", ActionType: ActionShow, Street: StreetShowdown},
+						{Player: "frank", PlayerID: "pqr", ActionType: ActionCollect, Amount: 200, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "eve", Amount: 0, HandCards: []string{"Jc", "Jd"}},
-						{Player: "frank", Amount: 200, HandCards: []string{"As", "Ts"}},
+						{Player: "eve", PlayerID: "mno", Amount: 0, HandCards: []string{"Jc", "Jd"}},
+						{Player: "frank", PlayerID: "pqr", Amount: 200, HandCards: []string{"As", "Ts"}},
 					},
 				},
 			},
@@ -329,25 +337,26 @@ func TestParseHands(t *testing.T) {
 					StartTime:  baseTime,
 					SmallBlind: 10,
 					BigBlind:   20,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "grace @ stu", DisplayName: "grace", Stack: 500},
-						{SeatNumber: 2, Name: "henry @ vwx", DisplayName: "henry", Stack: 500},
+						{SeatNumber: 1, Name: "grace @ stu", DisplayName: "grace", ID: "stu", Stack: 500},
+						{SeatNumber: 2, Name: "henry @ vwx", DisplayName: "henry", ID: "vwx", Stack: 500},
 					},
 					HeroCards: []string{"Qh", "Qd"},
 					Board: Board{
 						Flop: []string{"2c", "7d", "9s"},
 					},
 					Actions: []Action{
-						{Player: "grace", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
-						{Player: "henry", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
-						{Player: "grace", ActionType: ActionRaise, Amount: 60, Street: StreetPreflop},
-						{Player: "henry", ActionType: ActionCall, Amount: 40, Street: StreetPreflop},
-						{Player: "grace", ActionType: ActionBet, Amount: 100, Street: StreetFlop},
-						{Player: "henry", ActionType: ActionFold, Street: StreetFlop},
-						{Player: "grace", ActionType: ActionCollect, Amount: 120, Street: StreetShowdown},
+						{Player: "grace", PlayerID: "stu", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+						{Player: "henry", PlayerID: "vwx", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+						{Player: "grace", PlayerID: "stu", ActionType: ActionRaise, Amount: 60, Street: StreetPreflop},
+						{Player: "henry", PlayerID: "vwx", ActionType: ActionCall, Amount: 40, Street: StreetPreflop},
+						{Player: "grace", PlayerID: "stu", ActionType: ActionBet, Amount: 100, Street: StreetFlop},
+						{Player: "henry", PlayerID: "vwx", ActionType: ActionFold, Street: StreetFlop},
+						{Player: "grace", PlayerID: "stu", ActionType: ActionCollect, Amount: 120, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "grace", Amount: 120},
+						{Player: "grace", PlayerID: "stu", Amount: 120},
 					},
 				},
 			},
@@ -374,29 +383,31 @@ func TestParseHands(t *testing.T) {
 					HandNumber: "6",
 					HandID:     "10168127831822994649",
 					Dealer:     "iris",
+					DealerID:   "yza",
 					StartTime:  baseTime,
 					SmallBlind: 10,
 					BigBlind:   20,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "iris @ yza", DisplayName: "iris", Stack: 1000},
-						{SeatNumber: 2, Name: "john @ bcd", DisplayName: "john", Stack: 300},
+						{SeatNumber: 1, Name: "iris @ yza", DisplayName: "iris", ID: "yza", Stack: 1000},
+						{SeatNumber: 2, Name: "john @ bcd", DisplayName: "john", ID: "bcd", Stack: 300},
 					},
 					HeroCards: []string{"9h", "9d"},
 					Board: Board{
 						Flop: []string{"Ah", "Kd", "Qs"},
 					},
 					Actions: []Action{
-						{Player: "iris", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
-						{Player: "john", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
-						{Player: "iris", ActionType: ActionRaise, Amount: 60, Street: StreetPreflop},
-						{Player: "john", ActionType: ActionCall, Amount: 280, Street: StreetPreflop, IsAllIn: true},
-						{Player: "iris", ActionType: ActionShow, Street: StreetShowdown},
-						{Player: "john", ActionType: ActionShow, Street: StreetShowdown},
-						{Player: "iris", ActionType: ActionCollect, Amount: 600, Street: StreetShowdown},
+						{Player: "iris", PlayerID: "yza", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+						{Player: "john", PlayerID: "bcd", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+						{Player: "iris", PlayerID: "yza", ActionType: ActionRaise, Amount: 60, Street: StreetPreflop},
+						{Player: "john", PlayerID: "bcd", ActionType: ActionCall, Amount: 280, Street: StreetPreflop, IsAllIn: true},
+						{Player: "iris", PlayerID: "yza", ActionType: ActionShow, Street: StreetShowdown},
+						{Player: "john", PlayerID: "bcd", ActionType: ActionShow, Street: StreetShowdown},
+						{Player: "iris", PlayerID: "yza", ActionType: ActionCollect, Amount: 600, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "iris", Amount: 600, HandCards: []string{"Ac", "Kh"}},
-						{Player: "john", Amount: 0, HandCards: []string{"9s", "9c"}},
+						{Player: "iris", PlayerID: "yza", Amount: 600, HandCards: []string{"Ac", "Kh"}},
+						{Player: "john", PlayerID: "bcd", Amount: 0, HandCards: []string{"9s", "9c"}},
 					},
 				},
 			},
@@ -425,31 +436,33 @@ func TestParseHands(t *testing.T) {
 					HandNumber: "7",
 					HandID:     "13372294122307939540",
 					Dealer:     "kate",
+					DealerID:   "efg",
 					StartTime:  baseTime,
 					SmallBlind: 10,
 					BigBlind:   20,
+					Level:      1,
 					Players: []Player{
-						{SeatNumber: 1, Name: "kate @ efg", DisplayName: "kate", Stack: 500},
-						{SeatNumber: 2, Name: "leo @ hij", DisplayName: "leo", Stack: 600},
-						{SeatNumber: 3, Name: "mike @ klm", DisplayName: "mike", Stack: 700},
+						{SeatNumber: 1, Name: "kate @ efg", DisplayName: "kate", ID: "efg", Stack: 500},
+						{SeatNumber: 2, Name: "leo @ hij", DisplayName: "leo", ID: "hij", Stack: 600},
+						{SeatNumber: 3, Name: "mike @ klm", DisplayName: "mike", ID: "klm", Stack: 700},
 					},
 					HeroCards: []string{"5h", "6d"},
 					Board: Board{
 						Flop: []string{"5h", "6d", "7s"},
 					},
 					Actions: []Action{
-						{Player: "kate", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
-						{Player: "leo", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
-						{Player: "mike", ActionType: ActionFold, Street: StreetPreflop},
-						{Player: "kate", ActionType: ActionCall, Amount: 10, Street: StreetPreflop},
-						{Player: "leo", ActionType: ActionCheck, Street: StreetPreflop},
-						{Player: "kate", ActionType: ActionCheck, Street: StreetFlop},
-						{Player: "leo", ActionType: ActionBet, Amount: 40, Street: StreetFlop},
-						{Player: "kate", ActionType: ActionFold, Street: StreetFlop},
-						{Player: "leo", ActionType: ActionCollect, Amount: 40, Street: StreetShowdown},
+						{Player: "kate", PlayerID: "efg", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+						{Player: "leo", PlayerID: "hij", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+						{Player: "mike", PlayerID: "klm", ActionType: ActionFold, Street: StreetPreflop},
+						{Player: "kate", PlayerID: "efg", ActionType: ActionCall, Amount: 10, Street: StreetPreflop},
+						{Player: "leo", PlayerID: "hij", ActionType: ActionCheck, Street: StreetPreflop},
+						{Player: "kate", PlayerID: "efg", ActionType: ActionCheck, Street: StreetFlop},
+						{Player: "leo", PlayerID: "hij", ActionType: ActionBet, Amount: 40, Street: StreetFlop},
+						{Player: "kate", PlayerID: "efg", ActionType: ActionFold, Street: StreetFlop},
+						{Player: "leo", PlayerID: "hij", ActionType: ActionCollect, Amount: 40, Street: StreetShowdown},
 					},
 					Winners: []Winner{
-						{Player: "leo", Amount: 40},
+						{Player: "leo", PlayerID: "hij", Amount: 40},
 					},
 				},
 			},
@@ -750,3 +763,660 @@ func TestCashGameRaiseFormat(t *testing.T) {
 		t.Error("Cash game output should contain 'raises $120 to $180'")
 	}
 }
+
+func TestGGPokerCashFormat(t *testing.T) {
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,10
+"""player2 @ id2"" collected 120 from pot",2025-11-15T05:09:14.567Z,9
+"""player1 @ id1"" folds",2025-11-15T05:09:14.567Z,8
+"""player2 @ id2"" raises to 180",2025-11-15T05:09:14.567Z,7
+"""player1 @ id1"" raises to 60",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,3
+"Player stacks: #1 ""player1 @ id1"" (1500) | #2 ""player2 @ id2"" (1500)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,1`
+
+	opts := ConvertOptions{
+		HeroName:     "player1",
+		SiteName:     "PokerStars",
+		TimeLocation: time.UTC,
+		GameType:     GameTypeCash,
+		OutputFormat: OutputFormatGGPoker,
+	}
+
+	result, err := ParseCSV(strings.NewReader(csv), opts)
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+
+	output := string(result.HH)
+	assertInOrder(t, output, []string{
+		"Poker Hand #HD",
+		": Hold'em No Limit ($10/$20) - 2025/11/15 05:09:14\n",
+		"Table 'Poker Now' 2-max Seat #1 is the button\n",
+		"Seat 1: player1 ($1500 in chips)\n",
+		"player1: posts small blind $10\n",
+		"player2: posts big blind $20\n",
+		"*** HOLE CARDS ***\n",
+		"Dealt to player1 [Ah Kh]\n",
+		"Dealt to player2 \n",
+		"player1: raises $40 to $60\n",
+		"player2: raises $120 to $180\n",
+		"player1: folds\n",
+		"*** SHOWDOWN ***\n",
+		"player2 collected $120 from pot\n",
+		"*** SUMMARY ***\n",
+		"Total pot $120 | Rake $0 | Jackpot $0 | Bingo $0 | Fortune $0 | Tax $0\n",
+		"Seat 2: player2 (big blind) won ($120)\n",
+	})
+	if strings.Contains(output, "doesn't show hand") {
+		t.Error("GGPoker output should not contain \"doesn't show hand\"")
+	}
+}
+
+func TestCashGameDecimalAmounts(t *testing.T) {
+	// Test that cent-denominated blinds and bets are parsed and formatted without float drift
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,12
+"""player2 @ id2"" collected 1.50 from pot",2025-11-15T05:09:14.567Z,11
+"Uncalled bet of 0.60 returned to ""player2 @ id2""",2025-11-15T05:09:14.567Z,10
+"""player1 @ id1"" folds",2025-11-15T05:09:14.567Z,9
+"""player2 @ id2"" bets 0.60",2025-11-15T05:09:14.567Z,8
+"Flop:  [A♥, K♦, Q♠]",2025-11-15T05:09:14.567Z,7
+"""player2 @ id2"" calls 0.75",2025-11-15T05:09:14.567Z,6
+"""player1 @ id1"" raises to 0.75",2025-11-15T05:09:14.567Z,5
+"""player2 @ id2"" posts a big blind of 0.25",2025-11-15T05:09:14.567Z,4
+"""player1 @ id1"" posts a small blind of 0.10",2025-11-15T05:09:14.567Z,3
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,2
+"Player stacks: #1 ""player1 @ id1"" (25.30) | #2 ""player2 @ id2"" (19.85)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,0`
+
+	reader := strings.NewReader(csv)
+	opts := ConvertOptions{
+		HeroName:     "player1",
+		SiteName:     "PokerStars",
+		TimeLocation: time.UTC,
+		GameType:     GameTypeCash,
+	}
+
+	result, err := ParseCSV(reader, opts)
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+	if result.SkippedHands != 0 {
+		t.Fatalf("SkippedHands = %d, want 0", result.SkippedHands)
+	}
+
+	output := string(result.HH)
+
+	wantLines := []string{
+		"Hold'em No Limit ($0.10/$0.25 USD)",
+		"Seat 1: player1 ($25.30 in chips)",
+		"Seat 2: player2 ($19.85 in chips)",
+		"player1: posts small blind $0.10",
+		"player2: posts big blind $0.25",
+		"player1: raises $0.50 to $0.75",
+		"player2: calls $0.50",
+		"player2: bets $0.60",
+		"Uncalled bet ($0.60) returned to player2",
+		"player2 collected $1.50 from pot",
+		"Total pot $1.50 | Rake $0",
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(output, want) {
+			t.Errorf("Cash game output should contain %q\nGot:\n%s", want, output)
+		}
+	}
+	assertPokerStarsRoundTrip(t, output, opts)
+}
+
+func TestParsePokerNowGame(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantGame  PokerGame
+		wantLimit BetLimit
+		wantOK    bool
+	}{
+		{
+			name:      "No Limit Hold'em",
+			input:     "No Limit Texas Hold'em",
+			wantGame:  PokerGameHoldem,
+			wantLimit: BetLimitNoLimit,
+			wantOK:    true,
+		},
+		{
+			name:      "Pot Limit Omaha Hi",
+			input:     "Pot Limit Omaha Hi",
+			wantGame:  PokerGameOmaha,
+			wantLimit: BetLimitPotLimit,
+			wantOK:    true,
+		},
+		{
+			name:      "Pot Limit Omaha",
+			input:     "Pot Limit Omaha",
+			wantGame:  PokerGameOmaha,
+			wantLimit: BetLimitPotLimit,
+			wantOK:    true,
+		},
+		{
+			name:      "Pot Limit Omaha Hi 5 Cards",
+			input:     "Pot Limit Omaha Hi 5 Cards",
+			wantGame:  PokerGameOmaha5,
+			wantLimit: BetLimitPotLimit,
+			wantOK:    true,
+		},
+		{
+			name:      "Pot Limit Omaha Hi 6 Cards",
+			input:     "Pot Limit Omaha Hi 6 Cards",
+			wantGame:  PokerGameOmaha6,
+			wantLimit: BetLimitPotLimit,
+			wantOK:    true,
+		},
+		{
+			name:   "Omaha Hi/Lo is unsupported",
+			input:  "Pot Limit Omaha Hi/Lo",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, limit, ok := parsePokerNowGame(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("parsePokerNowGame(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if game != tt.wantGame || limit != tt.wantLimit {
+				t.Errorf("parsePokerNowGame(%q) = (%v, %v), want (%v, %v)", tt.input, game, limit, tt.wantGame, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestOmahaHandFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		game       string
+		heroCards  string
+		showCards  string
+		wantHeader string
+		wantDealt  string
+		wantShows  string
+	}{
+		{
+			name:       "PLO",
+			game:       "Pot Limit Omaha Hi",
+			heroCards:  "A♥, K♥, Q♦, J♦",
+			showCards:  "9♣, 9♦, 8♠, 7♠",
+			wantHeader: "Omaha Pot Limit ($10/$20 USD)",
+			wantDealt:  "Dealt to player1 [Ah Kh Qd Jd]",
+			wantShows:  "player2: shows [9c 9d 8s 7s]",
+		},
+		{
+			name:       "PLO5",
+			game:       "Pot Limit Omaha Hi",
+			heroCards:  "A♥, K♥, Q♦, J♦, 2♣",
+			showCards:  "9♣, 9♦, 8♠, 7♠, 3♣",
+			wantHeader: "5 Card Omaha Pot Limit ($10/$20 USD)",
+			wantDealt:  "Dealt to player1 [Ah Kh Qd Jd 2c]",
+			wantShows:  "player2: shows [9c 9d 8s 7s 3c]",
+		},
+		{
+			name:       "PLO6",
+			game:       "Pot Limit Omaha Hi 6 Cards",
+			heroCards:  "A♥, K♥, Q♦, J♦, 2♣, 3♣",
+			showCards:  "9♣, 9♦, 8♠, 7♠, 4♣, 5♣",
+			wantHeader: "6 Card Omaha Pot Limit ($10/$20 USD)",
+			wantDealt:  "Dealt to player1 [Ah Kh Qd Jd 2c 3c]",
+			wantShows:  "player2: shows [9c 9d 8s 7s 4c 5c]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,14
+"""player2 @ id2"" collected 40 from pot",2025-11-15T05:09:14.567Z,13
+"""player2 @ id2"" shows a ` + tt.showCards + `.",2025-11-15T05:09:14.567Z,12
+"""player1 @ id1"" shows a ` + tt.heroCards + `.",2025-11-15T05:09:14.567Z,11
+"""player2 @ id2"" checks",2025-11-15T05:09:14.567Z,10
+"""player1 @ id1"" checks",2025-11-15T05:09:14.567Z,9
+"River: 2♥, 3♦, 4♠, 5♥ [6♦]",2025-11-15T05:09:14.567Z,8
+"Turn: 2♥, 3♦, 4♠ [5♥]",2025-11-15T05:09:14.567Z,7
+"Flop:  [2♥, 3♦, 4♠]",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" checks",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" calls 20",2025-11-15T05:09:14.567Z,4
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,3
+"""player1 @ id1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,2
+"Your hand is ` + tt.heroCards + `",2025-11-15T05:09:14.567Z,1
+"Player stacks: #1 ""player1 @ id1"" (1500) | #2 ""player2 @ id2"" (1500)",2025-11-15T05:09:14.567Z,0
+"-- starting hand #1 (id: test123) (` + tt.game + `) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,-1`
+
+			opts := ConvertOptions{
+				HeroName:     "player1",
+				SiteName:     "PokerStars",
+				TimeLocation: time.UTC,
+				GameType:     GameTypeCash,
+			}
+
+			result, err := ParseCSV(strings.NewReader(csv), opts)
+			if err != nil {
+				t.Fatalf("ParseCSV() failed: %v", err)
+			}
+			if result.SkippedHands != 0 {
+				t.Fatalf("SkippedHands = %d, want 0 (%+v)", result.SkippedHands, result.SkippedHandsInfo)
+			}
+
+			output := string(result.HH)
+			for _, want := range []string{tt.wantHeader, tt.wantDealt, tt.wantShows} {
+				if !strings.Contains(output, want) {
+					t.Errorf("Omaha output should contain %q\nGot:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestParseHands_UnsupportedGame(t *testing.T) {
+	baseTime := time.Date(2025, 11, 15, 5, 9, 14, 567000000, time.UTC)
+	entries := []LogEntry{
+		{Entry: `-- starting hand #1 (id: abc) (Pot Limit Omaha Hi/Lo) (dealer: "player1 @ id1") --`, At: baseTime, Order: 1},
+		{Entry: `Player stacks: #1 "player1 @ id1" (1000) | #2 "player2 @ id2" (1000)`, At: baseTime, Order: 2},
+		{Entry: `Your hand is A♥, K♥, Q♦, J♦`, At: baseTime, Order: 3},
+		{Entry: `-- ending hand #1 --`, At: baseTime, Order: 4},
+		{Entry: `-- starting hand #2 (id: def) (No Limit Texas Hold'em) (dealer: "player2 @ id2") --`, At: baseTime, Order: 5},
+		{Entry: `Player stacks: #1 "player1 @ id1" (1000) | #2 "player2 @ id2" (1000)`, At: baseTime, Order: 6},
+		{Entry: `Your hand is A♥, K♥`, At: baseTime, Order: 7},
+		{Entry: `-- ending hand #2 --`, At: baseTime, Order: 8},
+	}
+
+	hands, skipped, skippedInfo, err := ParseHands(entries, ConvertOptions{HeroName: "player1"})
+	if err != nil {
+		t.Fatalf("ParseHands() unexpected error: %v", err)
+	}
+	if len(hands) != 1 || hands[0].HandNumber != "2" {
+		t.Fatalf("ParseHands() hands = %+v, want only hand #2", hands)
+	}
+	if skipped != 1 || len(skippedInfo) != 1 {
+		t.Fatalf("ParseHands() skipped = %d (%d infos), want 1", skipped, len(skippedInfo))
+	}
+	if skippedInfo[0].Reason != SkipReasonUnsupportedGame {
+		t.Errorf("skip reason = %q, want %q", skippedInfo[0].Reason, SkipReasonUnsupportedGame)
+	}
+	if len(skippedInfo[0].RawInput) != 4 {
+		t.Errorf("raw input has %d entries, want 4", len(skippedInfo[0].RawInput))
+	}
+}
+
+func TestRunItTwice(t *testing.T) {
+	baseTime := time.Date(2025, 11, 15, 5, 9, 14, 567000000, time.UTC)
+
+	t.Run("all-in preflop runs two full boards", func(t *testing.T) {
+		entries := []LogEntry{
+			{Entry: `-- starting hand #1 (id: abc) (No Limit Texas Hold'em) (dealer: "alice @ a1") --`, At: baseTime, Order: 1},
+			{Entry: `Player stacks: #1 "alice @ a1" (1000) | #2 "bob @ b2" (1000)`, At: baseTime, Order: 2},
+			{Entry: `Your hand is A♥, A♦`, At: baseTime, Order: 3},
+			{Entry: `"alice @ a1" posts a small blind of 10`, At: baseTime, Order: 4},
+			{Entry: `"bob @ b2" posts a big blind of 20`, At: baseTime, Order: 5},
+			{Entry: `"alice @ a1" raises to 1000 and go all in`, At: baseTime, Order: 6},
+			{Entry: `"bob @ b2" calls 1000 and go all in`, At: baseTime, Order: 7},
+			{Entry: `"alice @ a1" shows a A♥, A♦.`, At: baseTime, Order: 8},
+			{Entry: `"bob @ b2" shows a K♠, K♣.`, At: baseTime, Order: 9},
+			{Entry: `Flop:  [2♥, 7♦, 9♠]`, At: baseTime, Order: 10},
+			{Entry: `Turn: 2♥, 7♦, 9♠ [J♣]`, At: baseTime, Order: 11},
+			{Entry: `River: 2♥, 7♦, 9♠, J♣ [3♦]`, At: baseTime, Order: 12},
+			{Entry: `Flop (second run):  [K♦, 4♣, 5♥]`, At: baseTime, Order: 13},
+			{Entry: `Turn (second run): K♦, 4♣, 5♥ [8♠]`, At: baseTime, Order: 14},
+			{Entry: `River (second run): K♦, 4♣, 5♥, 8♠ [Q♥]`, At: baseTime, Order: 15},
+			{Entry: `"alice @ a1" collected 1000 from pot with Pair, A's`, At: baseTime, Order: 16},
+			{Entry: `"bob @ b2" collected 1000 from pot with Three of a Kind, K's`, At: baseTime, Order: 17},
+			{Entry: `-- ending hand #1 --`, At: baseTime, Order: 18},
+		}
+
+		hands, _, _, err := ParseHands(entries, ConvertOptions{HeroName: "alice"})
+		if err != nil {
+			t.Fatalf("ParseHands() unexpected error: %v", err)
+		}
+		if len(hands) != 1 {
+			t.Fatalf("ParseHands() returned %d hands, want 1", len(hands))
+		}
+
+		wantBoard := Board{
+			Flop:  []string{"2h", "7d", "9s"},
+			Turn:  "Jc",
+			River: "3d",
+			Runs: []BoardRun{
+				{Flop: []string{"Kd", "4c", "5h"}, Turn: "8s", River: "Qh"},
+			},
+		}
+		if diff := cmp.Diff(wantBoard, hands[0].Board); diff != "" {
+			t.Errorf("Board mismatch (-want +got):\n%s", diff)
+		}
+
+		wantWinners := []Winner{
+			{Player: "alice", PlayerID: "a1", Amount: 1000, HandCards: []string{"Ah", "Ad"}, HandName: "a pair of Aces", Run: 0},
+			{Player: "bob", PlayerID: "b2", Amount: 1000, HandCards: []string{"Ks", "Kc"}, HandName: "three of a kind, Kings", Run: 1},
+		}
+		if diff := cmp.Diff(wantWinners, hands[0].Winners); diff != "" {
+			t.Errorf("Winners mismatch (-want +got):\n%s", diff)
+		}
+
+		output := convertHandToHH(hands[0], ConvertOptions{HeroName: "alice", SiteName: "PokerStars", TimeLocation: time.UTC}, pokerStarsDialect, "1")
+		wantInOrder := []string{
+			"*** FIRST FLOP *** [2h 7d 9s]",
+			"*** FIRST TURN *** [2h 7d 9s] [Jc]",
+			"*** FIRST RIVER *** [2h 7d 9s Jc] [3d]",
+			"*** SECOND FLOP *** [Kd 4c 5h]",
+			"*** SECOND TURN *** [Kd 4c 5h] [8s]",
+			"*** SECOND RIVER *** [Kd 4c 5h 8s] [Qh]",
+			"*** FIRST SHOW DOWN ***",
+			"alice: shows [Ah Ad] (a pair of Aces)",
+			"bob: shows [Ks Kc] (three of a kind, Kings)",
+			"alice collected 1000 from pot",
+			"*** SECOND SHOW DOWN ***",
+			"bob collected 1000 from pot",
+			"*** SUMMARY ***",
+			"Total pot 2000 | Rake 0",
+			"Hand was run twice",
+			"FIRST Board [2h 7d 9s Jc 3d]",
+			"SECOND Board [Kd 4c 5h 8s Qh]",
+			"Seat 1: alice (small blind) showed [Ah Ad] and won (1000) with a pair of Aces",
+			"Seat 2: bob (big blind) showed [Ks Kc] and won (1000) with three of a kind, Kings",
+		}
+		assertInOrder(t, output, wantInOrder)
+		if strings.Contains(output, "*** FLOP ***") {
+			t.Errorf("run-it-twice output should not contain a shared FLOP header\nGot:\n%s", output)
+		}
+		assertPokerStarsRoundTrip(t, output, ConvertOptions{HeroName: "alice", SiteName: "PokerStars", TimeLocation: time.UTC})
+	})
+
+	t.Run("all-in on the turn shares flop and turn", func(t *testing.T) {
+		entries := []LogEntry{
+			{Entry: `-- starting hand #2 (id: def) (No Limit Texas Hold'em) (dealer: "alice @ a1") --`, At: baseTime, Order: 1},
+			{Entry: `Player stacks: #1 "alice @ a1" (1000) | #2 "bob @ b2" (1001)`, At: baseTime, Order: 2},
+			{Entry: `Your hand is A♥, A♦`, At: baseTime, Order: 3},
+			{Entry: `"alice @ a1" posts a small blind of 10`, At: baseTime, Order: 4},
+			{Entry: `"bob @ b2" posts a big blind of 20`, At: baseTime, Order: 5},
+			{Entry: `"alice @ a1" calls 20`, At: baseTime, Order: 6},
+			{Entry: `"bob @ b2" checks`, At: baseTime, Order: 7},
+			{Entry: `Flop:  [2♥, 7♦, 9♠]`, At: baseTime, Order: 8},
+			{Entry: `"bob @ b2" checks`, At: baseTime, Order: 9},
+			{Entry: `"alice @ a1" checks`, At: baseTime, Order: 10},
+			{Entry: `Turn: 2♥, 7♦, 9♠ [J♣]`, At: baseTime, Order: 11},
+			{Entry: `"bob @ b2" bets 980 and go all in`, At: baseTime, Order: 12},
+			{Entry: `"alice @ a1" calls 980 and go all in`, At: baseTime, Order: 13},
+			{Entry: `"alice @ a1" shows a A♥, A♦.`, At: baseTime, Order: 14},
+			{Entry: `"bob @ b2" shows a J♠, J♦.`, At: baseTime, Order: 15},
+			{Entry: `River: 2♥, 7♦, 9♠, J♣ [A♣]`, At: baseTime, Order: 16},
+			{Entry: `River (second run): 2♥, 7♦, 9♠, J♣ [5♦]`, At: baseTime, Order: 17},
+			{Entry: `"alice @ a1" collected 1000 from pot`, At: baseTime, Order: 18},
+			{Entry: `"bob @ b2" collected 1000 from pot`, At: baseTime, Order: 19},
+			{Entry: `-- ending hand #2 --`, At: baseTime, Order: 20},
+		}
+
+		hands, _, _, err := ParseHands(entries, ConvertOptions{HeroName: "alice"})
+		if err != nil {
+			t.Fatalf("ParseHands() unexpected error: %v", err)
+		}
+
+		wantRuns := []BoardRun{
+			{Flop: []string{"2h", "7d", "9s"}, Turn: "Jc", River: "5d"},
+		}
+		if diff := cmp.Diff(wantRuns, hands[0].Board.Runs); diff != "" {
+			t.Errorf("Runs mismatch (-want +got):\n%s", diff)
+		}
+
+		output := convertHandToHH(hands[0], ConvertOptions{HeroName: "alice", SiteName: "PokerStars", TimeLocation: time.UTC}, pokerStarsDialect, "2")
+		assertInOrder(t, output, []string{
+			"*** FLOP *** [2h 7d 9s]",
+			"*** TURN *** [2h 7d 9s] [Jc]",
+			"bob: bets 980 and is all-in",
+			"*** FIRST RIVER *** [2h 7d 9s Jc] [Ac]",
+			"*** SECOND RIVER *** [2h 7d 9s Jc] [5d]",
+			"*** FIRST SHOW DOWN ***",
+			"alice collected 1000 from pot",
+			"*** SECOND SHOW DOWN ***",
+			"bob collected 1000 from pot",
+			"FIRST Board [2h 7d 9s Jc Ac]",
+			"SECOND Board [2h 7d 9s Jc 5d]",
+		})
+		assertPokerStarsRoundTrip(t, output, ConvertOptions{HeroName: "alice", SiteName: "PokerStars", TimeLocation: time.UTC})
+	})
+}
+
+// assertInOrder checks that every expected line appears in output, in the given order
+func assertInOrder(t *testing.T, output string, want []string) {
+	t.Helper()
+	rest := output
+	for _, w := range want {
+		idx := strings.Index(rest, w)
+		if idx < 0 {
+			t.Errorf("output should contain %q after the previous expected line\nGot:\n%s", w, output)
+			return
+		}
+		rest = rest[idx+len(w):]
+	}
+}
+
+func TestStraddleAndDeadBlinds(t *testing.T) {
+	// player4 straddles, player5 returns and posts both missing blinds
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,20
+"""player3 @ id3"" collected 840 from pot",2025-11-15T05:09:14.567Z,19
+"Uncalled bet of 240 returned to ""player3 @ id3""",2025-11-15T05:09:14.567Z,18
+"""player4 @ id4"" folds",2025-11-15T05:09:14.567Z,17
+"""player3 @ id3"" bets 240",2025-11-15T05:09:14.567Z,16
+"Flop:  [A♥, K♦, Q♠]",2025-11-15T05:09:14.567Z,15
+"""player5 @ id5"" folds",2025-11-15T05:09:14.567Z,14
+"""player4 @ id4"" calls 240",2025-11-15T05:09:14.567Z,13
+"""player3 @ id3"" raises to 240",2025-11-15T05:09:14.567Z,12
+"""player2 @ id2"" folds",2025-11-15T05:09:14.567Z,11
+"""player1 @ id1"" folds",2025-11-15T05:09:14.567Z,10
+"""player5 @ id5"" raises to 120",2025-11-15T05:09:14.567Z,9
+"""player5 @ id5"" posts a missing big blind of 20",2025-11-15T05:09:14.567Z,8
+"""player5 @ id5"" posts a missing small blind of 10",2025-11-15T05:09:14.567Z,7
+"""player4 @ id4"" posts a straddle of 40",2025-11-15T05:09:14.567Z,6
+"""player3 @ id3"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""player2 @ id2"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,3
+"Player stacks: #1 ""player1 @ id1"" (1500) | #2 ""player2 @ id2"" (1500) | #3 ""player3 @ id3"" (1500) | #4 ""player4 @ id4"" (1500) | #5 ""player5 @ id5"" (1500)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,1`
+
+	opts := ConvertOptions{
+		HeroName:     "player1",
+		SiteName:     "PokerStars",
+		TimeLocation: time.UTC,
+		GameType:     GameTypeCash,
+	}
+
+	result, err := ParseCSV(strings.NewReader(csv), opts)
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+
+	assertInOrder(t, string(result.HH), []string{
+		"player2: posts small blind $10",
+		"player3: posts big blind $20",
+		"player4: posts straddle $40",
+		"player5: posts small & big blinds $30",
+		// The live missing big blind (20) is already in front of player5
+		"player5: raises $80 to $120",
+		"player1: folds",
+		"player2: folds",
+		// player3 has the big blind (20) in front
+		"player3: raises $120 to $240",
+		// player4 has the straddle (40) in front
+		"player4: calls $200",
+		"player5: folds",
+		"*** FLOP *** [Ah Kd Qs]",
+		"player3: bets $240",
+		"player4: folds",
+		"Uncalled bet ($240) returned to player3",
+		"player3 collected $840 from pot",
+		"Seat 4: player4 folded on the Flop",
+		"Seat 5: player5 folded before Flop",
+	})
+
+	if strings.Contains(string(result.HH), "player5: posts big blind") {
+		t.Errorf("missing blinds posted together should be printed on a single line\nGot:\n%s", result.HH)
+	}
+	assertPokerStarsRoundTrip(t, string(result.HH), opts)
+}
+
+func TestParse_UnrecognizedEntries(t *testing.T) {
+	// Session events and the dead small blind marker are known; the two other entries are not
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,12
+"""player2 @ id2"" collected 20 from pot",2025-11-15T05:09:14.567Z,11
+"Uncalled bet of 10 returned to ""player2 @ id2""",2025-11-15T05:09:14.567Z,10
+"""player1 @ id1"" mucks",2025-11-15T05:09:14.567Z,9
+"""player1 @ id1"" folds",2025-11-15T05:09:14.567Z,8
+"The player ""player3 @ id3"" requested a seat.",2025-11-15T05:09:14.567Z,7
+"""player1 @ id1"" used a time bank of 30 seconds",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Dead Small Blind",2025-11-15T05:09:14.567Z,3
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,2
+"Player stacks: #1 ""player1 @ id1"" (1500) | #2 ""player2 @ id2"" (1500)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,0
+"The player ""player1 @ id1"" joined the game with a stack of 1500.",2025-11-15T05:09:14.567Z,-1`
+
+	result, err := Parse(strings.NewReader(csv), ConvertOptions{HeroName: "player1", TimeLocation: time.UTC})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	want := []Diagnostic{
+		{HandNumber: "1", Order: 6, Entry: `"player1 @ id1" used a time bank of 30 seconds`},
+		{HandNumber: "1", Order: 9, Entry: `"player1 @ id1" mucks`},
+	}
+	if diff := cmp.Diff(want, result.Diagnostics); diff != "" {
+		t.Errorf("Diagnostics mismatch (-want +got):\n%s", diff)
+	}
+	if result.SkippedHands != 0 || !strings.Contains(string(result.HH), "player2 collected 20 from pot") {
+		t.Errorf("the hand should still be converted, got:\n%s", result.HH)
+	}
+}
+
+func TestParse_Warnings(t *testing.T) {
+	// Hand #1 has a non-numeric ID and seats 3 and 7; hand #2 is already numbered 1 to N but is never closed
+	csv := `entry,at,order
+"-- starting hand #2 (id: 99) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:10:14.567Z,10
+"Player stacks: #1 ""player1 @ id1"" (1490) | #4 ""player2 @ id2"" (1510)",2025-11-15T05:10:14.567Z,11
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,9
+"""player2 @ id2"" collected 20 from pot",2025-11-15T05:09:14.567Z,8
+"Uncalled bet of 10 returned to ""player2 @ id2""",2025-11-15T05:09:14.567Z,7
+"""player1 @ id1"" folds",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,3
+"Player stacks: #3 ""player1 @ id1"" (1500) | #7 ""player2 @ id2"" (1500)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,1`
+
+	result, err := Parse(strings.NewReader(csv), ConvertOptions{HeroName: "player1", TimeLocation: time.UTC})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	handID := convertHandIDToNumeric("test123")
+	want := []Warning{
+		{Code: WarningHandIDHashed, HandID: handID, Message: `hand ID "test123" is not numeric and was replaced by a hash of it`},
+		{Code: WarningSeatsRenumbered, HandID: handID, Message: "seats 3, 7 were renumbered 1-2"},
+	}
+	if diff := cmp.Diff(want, result.Warnings); diff != "" {
+		t.Errorf("Warnings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseHands_BlindLevels(t *testing.T) {
+	baseTime := time.Date(2025, 11, 15, 3, 0, 0, 0, time.UTC)
+	hand := func(num string) []LogEntry {
+		return []LogEntry{
+			{Entry: `-- starting hand #` + num + ` (No Limit Texas Hold'em) (dealer: "player1 @ id1") --`, At: baseTime},
+			{Entry: `Player stacks: #1 "player1 @ id1" (1000) | #2 "player2 @ id2" (1000)`, At: baseTime},
+			{Entry: `Your hand is A♥, K♥`, At: baseTime},
+			{Entry: `"player1 @ id1" folds`, At: baseTime},
+			{Entry: `-- ending hand #` + num + ` --`, At: baseTime},
+		}
+	}
+	change := func(what, from, to string) LogEntry {
+		return LogEntry{Entry: "The game's " + what + " was changed from " + from + " to " + to + ".", At: baseTime}
+	}
+
+	var entries []LogEntry
+	// The structure set up before the first hand is level 1
+	entries = append(entries, change("small blind", "10", "200"), change("big blind", "20", "400"), change("ante", "0", "66"))
+	entries = append(entries, hand("1")...)
+	entries = append(entries, hand("2")...)
+	// small blind, big blind and ante changes logged together are a single level
+	entries = append(entries, change("small blind", "200", "300"), change("big blind", "400", "600"), change("ante", "66", "100"))
+	entries = append(entries, hand("3")...)
+	// A change that keeps the same value does not start a new level
+	entries = append(entries, change("ante", "100", "100"))
+	entries = append(entries, hand("4")...)
+	entries = append(entries, change("big blind", "600", "800"))
+	entries = append(entries, hand("5")...)
+
+	hands, _, _, err := ParseHands(entries, ConvertOptions{HeroName: "player1"})
+	if err != nil {
+		t.Fatalf("ParseHands() failed: %v", err)
+	}
+
+	want := []int{1, 1, 2, 2, 3}
+	if len(hands) != len(want) {
+		t.Fatalf("got %d hands, want %d", len(hands), len(want))
+	}
+	for i, h := range hands {
+		if h.Level != want[i] {
+			t.Errorf("hand #%s: Level = %d, want %d", h.HandNumber, h.Level, want[i])
+		}
+	}
+
+	output := convertHandToHH(hands[4], ConvertOptions{HeroName: "player1", SiteName: "PokerStars", TimeLocation: time.UTC}, pokerStarsDialect, "1")
+	if !strings.Contains(output, "Hold'em No Limit - Level III (") {
+		t.Errorf("tournament header should contain the Roman numeral level\nGot:\n%s", output)
+	}
+}
+
+func TestShowdownHandNames(t *testing.T) {
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,13
+"""player1 @ id1"" collected 60 from pot with Two Pair, K's & 9's (combination: K♥, K♠, 9♦, 9♣, 2♠)",2025-11-15T05:09:14.567Z,12
+"""player2 @ id2"" shows a Q♥, J♥.",2025-11-15T05:09:14.567Z,11
+"""player1 @ id1"" shows a K♥, 9♦.",2025-11-15T05:09:14.567Z,10
+"River: K♠, 9♣, 2♠, 4♦ [3♣]",2025-11-15T05:09:14.567Z,9
+"Turn: K♠, 9♣, 2♠ [4♦]",2025-11-15T05:09:14.567Z,8
+"Flop:  [K♠, 9♣, 2♠]",2025-11-15T05:09:14.567Z,7
+"""player2 @ id2"" checks",2025-11-15T05:09:14.567Z,6
+"""player3 @ id3"" calls 20",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" calls 20",2025-11-15T05:09:14.567Z,4
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,3
+"Your hand is 7♥, 2♣",2025-11-15T05:09:14.567Z,2
+"Player stacks: #1 ""player1 @ id1"" (1000) | #2 ""player2 @ id2"" (1000) | #3 ""player3 @ id3"" (1000)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: names1) (No Limit Texas Hold'em) (dealer: ""player3 @ id3"") --",2025-11-15T05:09:14.567Z,0`
+
+	opts := ConvertOptions{
+		HeroName:     "player3",
+		SiteName:     "PokerStars",
+		TimeLocation: time.UTC,
+	}
+
+	result, err := ParseCSV(strings.NewReader(csv), opts)
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+
+	assertInOrder(t, string(result.HH), []string{
+		"*** SHOW DOWN ***",
+		"player1: shows [Kh 9d] (two pair, Kings and Nines)",
+		"player2: shows [Qh Jh]\n",
+		"*** SUMMARY ***",
+		"Seat 1: player1 showed [Kh 9d] and won (60) with two pair, Kings and Nines",
+		"Seat 2: player2 (big blind) showed [Qh Jh] and lost",
+		// The hero reached showdown without showing
+		"Seat 3: player3 (button) mucked [7h 2c]",
+	})
+	assertPokerStarsRoundTrip(t, string(result.HH), opts)
+}
@@ -1,68 +1,213 @@
 package pokernow2gw
 
 import (
+	"bufio"
 	"encoding/csv"
+	"encoding/gob"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"slices"
 	"strconv"
 	"time"
 )
 
+// csvChunkSize is the number of rows kept in memory while streaming a CSV log.
+// Larger logs are spilled to temporary files in chunks of this size.
+var csvChunkSize = 50000
+
+// createTemp creates the temporary files chunks are spilled to (replaced in tests)
+var createTemp = os.CreateTemp
+
+// errNoTempFile is returned by spillEntries when no temporary file can be created,
+// e.g. in the browser, where the WASM build has no writable file system
+var errNoTempFile = errors.New("temporary files are not available")
+
+// csvChunk is a chunk of rows that did not fit in memory, in chronological order
+type csvChunk struct {
+	path    string     // 書き出した一時ファイル（空の場合は entries に保持）
+	entries []LogEntry // 一時ファイルを作れない環境でメモリに残したエントリ
+}
+
 // ReadCSV reads PokerNow CSV log from reader and returns LogEntry slice
 func ReadCSV(r io.Reader) ([]LogEntry, error) {
-	csvReader := csv.NewReader(r)
+	var entries []LogEntry
+	err := StreamCSV(r, func(entry LogEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// StreamCSV reads PokerNow CSV log from reader and calls fn for each entry in chronological order
+//
+// PokerNowのCSVは逆時系列（新しい→古い）なので、昇順で処理するには全行を読む必要がある。
+// メモリに保持するのは csvChunkSize 行までで、それを超える分はチャンクごとに反転して
+// 一時ファイルに書き出し、最後に読んだチャンク（最も古い行）から順に返す。
+// 一時ファイルを作れない環境（ブラウザの WASM など）ではチャンクをメモリに残す。
+func StreamCSV(r io.Reader, fn func(LogEntry) error) error {
+	// Spreadsheet exports may prepend a UTF-8 BOM, which would make a quoted header a bare quote
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(len(utf8BOM)); string(bom) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+	csvReader := csv.NewReader(br)
+	csvReader.ReuseRecord = true
 
 	// Read header
 	header, err := csvReader.Read()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		return &ParseError{Kind: ParseErrorHeader, Row: 1, Err: fmt.Errorf("failed to read CSV header: %w", err)}
 	}
-
-	// Validate header
-	if len(header) != 3 || header[0] != "entry" || header[1] != "at" || header[2] != "order" {
-		return nil, fmt.Errorf("invalid CSV header format: expected [entry,at,order], got %v", header)
+	if !isPokerNowCSVHeader(header) {
+		return &ParseError{Kind: ParseErrorHeader, Row: 1, Err: fmt.Errorf("invalid CSV header format: expected [entry,at,order], got %v", header)}
 	}
 
-	var entries []LogEntry
+	var chunks []csvChunk
+	defer func() {
+		for _, c := range chunks {
+			if c.path != "" {
+				os.Remove(c.path)
+			}
+		}
+	}()
+	canSpill := true
 
-	// Read all rows
-	for {
+	chunk := make([]LogEntry, 0, min(csvChunkSize, 1024))
+	for row := 2; ; row++ {
 		record, err := csvReader.Read()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to read CSV row: %w", err)
+			return &ParseError{Kind: ParseErrorRow, Row: row, Err: fmt.Errorf("failed to read CSV row: %w", err)}
 		}
 
-		if len(record) != 3 {
-			return nil, fmt.Errorf("invalid CSV row format: expected 3 columns, got %d", len(record))
+		entry, parseErr := parseCSVRecord(record)
+		if parseErr != nil {
+			parseErr.Row = row
+			return parseErr
 		}
+		chunk = append(chunk, entry)
 
-		// Parse timestamp
-		timestamp, err := time.Parse(time.RFC3339, record[1])
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse timestamp %q: %w", record[1], err)
+		if len(chunk) >= csvChunkSize {
+			reverseEntries(chunk)
+			if canSpill {
+				path, err := spillEntries(chunk)
+				if err == nil {
+					chunks = append(chunks, csvChunk{path: path})
+					chunk = chunk[:0]
+					continue
+				}
+				if !errors.Is(err, errNoTempFile) {
+					return err
+				}
+				canSpill = false
+			}
+			chunks = append(chunks, csvChunk{entries: chunk})
+			chunk = make([]LogEntry, 0, min(csvChunkSize, 1024))
 		}
+	}
 
-		// Parse order
-		order, err := strconv.ParseInt(record[2], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse order %q: %w", record[2], err)
+	// The rows read last are the oldest, so the in-memory chunk comes first,
+	// followed by the other chunks from the most recently read one.
+	reverseEntries(chunk)
+	for _, entry := range chunk {
+		if err := fn(entry); err != nil {
+			return err
 		}
+	}
+	for i := len(chunks) - 1; i >= 0; i-- {
+		if chunks[i].path == "" {
+			for _, entry := range chunks[i].entries {
+				if err := fn(entry); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := replaySpilledEntries(chunks[i].path, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-		entries = append(entries, LogEntry{
-			Entry: record[0],
-			At:    timestamp,
-			Order: order,
-		})
+// utf8BOM is the byte order mark some spreadsheet exports put at the start of a CSV
+const utf8BOM = "\ufeff"
+
+// isPokerNowCSVHeader reports whether a CSV record is exactly the PokerNow header (entry, at, order)
+func isPokerNowCSVHeader(record []string) bool {
+	return slices.Equal(record, []string{"entry", "at", "order"})
+}
+
+// parseCSVRecord converts one CSV row (entry, at, order) to a LogEntry
+// The caller fills in the row number of the returned error.
+func parseCSVRecord(record []string) (LogEntry, *ParseError) {
+	if len(record) != 3 {
+		return LogEntry{}, &ParseError{Kind: ParseErrorRow, Err: fmt.Errorf("invalid CSV row format: expected 3 columns, got %d", len(record))}
 	}
 
-	// PokerNowのCSVは逆時系列なので、昇順（古い→新しい）にソート
-	// orderフィールドは昇順で処理する必要があるため、反転させる
-	reverseEntries(entries)
+	// Parse order
+	order, err := strconv.ParseInt(record[2], 10, 64)
+	if err != nil {
+		return LogEntry{}, &ParseError{Kind: ParseErrorRow, Err: fmt.Errorf("failed to parse order %q: %w", record[2], err)}
+	}
 
-	return entries, nil
+	// Parse timestamp
+	timestamp, err := time.Parse(time.RFC3339, record[1])
+	if err != nil {
+		return LogEntry{}, &ParseError{Kind: ParseErrorTimestamp, Order: order, Err: fmt.Errorf("failed to parse timestamp %q: %w", record[1], err)}
+	}
+
+	return LogEntry{
+		Entry: record[0],
+		At:    timestamp,
+		Order: order,
+	}, nil
+}
+
+// spillEntries writes a chunk of entries to a temporary file and returns its path
+func spillEntries(entries []LogEntry) (string, error) {
+	file, err := createTemp("", "pokernow2gw-*.gob")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errNoTempFile, err)
+	}
+	if err := gob.NewEncoder(file).Encode(entries); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write CSV chunk: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write CSV chunk: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// replaySpilledEntries reads a chunk written by spillEntries and calls fn for each entry
+func replaySpilledEntries(path string, fn func(LogEntry) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read CSV chunk: %w", err)
+	}
+	defer file.Close()
+
+	var entries []LogEntry
+	if err := gob.NewDecoder(file).Decode(&entries); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read CSV chunk: %w", err)
+	}
+	for _, entry := range entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // reverseEntries reverses the slice in-place
@@ -0,0 +1,148 @@
+package pokernow2gw
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStreamCSV_Order(t *testing.T) {
+	csv := `entry,at,order
+"third",2025-11-15T05:09:16.000Z,3
+"second",2025-11-15T05:09:15.000Z,2
+"first",2025-11-15T05:09:14.000Z,1`
+
+	tests := []struct {
+		name       string
+		chunkSize  int
+		noTempFile bool
+	}{
+		{name: "in memory", chunkSize: 50000},
+		{name: "spilled one row per chunk", chunkSize: 1},
+		{name: "spilled with partial last chunk", chunkSize: 2},
+		{name: "chunks kept in memory without temporary files", chunkSize: 1, noTempFile: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(size int) { csvChunkSize = size }(csvChunkSize)
+			csvChunkSize = tt.chunkSize
+			if tt.noTempFile {
+				// As in the browser, where the WASM build cannot create files
+				defer func(fn func(string, string) (*os.File, error)) { createTemp = fn }(createTemp)
+				createTemp = func(string, string) (*os.File, error) { return nil, errors.ErrUnsupported }
+			}
+
+			var got []string
+			err := StreamCSV(strings.NewReader(csv), func(entry LogEntry) error {
+				got = append(got, entry.Entry)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("StreamCSV() failed: %v", err)
+			}
+			if diff := cmp.Diff([]string{"first", "second", "third"}, got); diff != "" {
+				t.Errorf("StreamCSV() order mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamCSV_Headers(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		wantErr bool
+	}{
+		{name: "plain", csv: "entry,at,order\n\"first\",2025-11-15T05:09:14.000Z,1\n"},
+		{name: "quoted with BOM and CRLF", csv: "\ufeff\"entry\",\"at\",\"order\"\r\n\"first\",2025-11-15T05:09:14.000Z,1\r\n"},
+		{name: "padded names", csv: "entry, at ,order\n\"first\",2025-11-15T05:09:14.000Z,1\n", wantErr: true},
+		{name: "extra column", csv: "entry,at,order,note\n\"first\",2025-11-15T05:09:14.000Z,1,\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ReadCSV(strings.NewReader(tt.csv))
+			if tt.wantErr {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) || parseErr.Kind != ParseErrorHeader {
+					t.Errorf("ReadCSV() error = %v, want a header ParseError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadCSV() failed: %v", err)
+			}
+			want := []LogEntry{{Entry: "first", At: time.Date(2025, 11, 15, 5, 9, 14, 0, time.UTC), Order: 1}}
+			if diff := cmp.Diff(want, entries); diff != "" {
+				t.Errorf("ReadCSV() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamCSV_SpilledSampleMatchesInMemory(t *testing.T) {
+	samplePath := "../../sample/input/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.csv"
+	if _, err := os.Stat(samplePath); os.IsNotExist(err) {
+		t.Skip("Sample file not found, skipping test")
+	}
+
+	convert := func(chunkSize int) *ConvertResult {
+		t.Helper()
+		defer func(size int) { csvChunkSize = size }(csvChunkSize)
+		csvChunkSize = chunkSize
+
+		file, err := os.Open(samplePath)
+		if err != nil {
+			t.Fatalf("Failed to open sample file: %v", err)
+		}
+		defer file.Close()
+
+		result, err := Parse(file, ConvertOptions{HeroName: "whywaita", SiteName: "PokerStars", TimeLocation: time.UTC})
+		if err != nil {
+			t.Fatalf("Parse() failed: %v", err)
+		}
+		return result
+	}
+
+	inMemory := convert(1 << 30)
+	spilled := convert(97)
+	if diff := cmp.Diff(string(inMemory.HH), string(spilled.HH)); diff != "" {
+		t.Errorf("output differs when the CSV is spilled to disk (-in memory +spilled):\n%s", diff)
+	}
+	if inMemory.SkippedHands != spilled.SkippedHands {
+		t.Errorf("SkippedHands = %d (spilled), want %d", spilled.SkippedHands, inMemory.SkippedHands)
+	}
+}
+
+func TestStreamCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		csv      string
+		wantErr  string
+		wantKind ParseErrorKind
+		wantRow  int
+	}{
+		{name: "invalid header", csv: "a,b,c\n", wantErr: "invalid CSV header format", wantKind: ParseErrorHeader, wantRow: 1},
+		{name: "invalid timestamp", csv: "entry,at,order\n\"x\",yesterday,1\n", wantErr: "failed to parse timestamp", wantKind: ParseErrorTimestamp, wantRow: 2},
+		{name: "invalid order", csv: "entry,at,order\n\"x\",2025-11-15T05:09:14.000Z,2\n\"y\",2025-11-15T05:09:14.000Z,first\n", wantErr: "failed to parse order", wantKind: ParseErrorRow, wantRow: 3},
+		{name: "missing column", csv: "entry,at,order\n\"x\",2025-11-15T05:09:14.000Z\n", wantErr: "failed to read CSV row", wantKind: ParseErrorRow, wantRow: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := StreamCSV(strings.NewReader(tt.csv), func(LogEntry) error { return nil })
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("StreamCSV() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Kind != tt.wantKind || parseErr.Row != tt.wantRow {
+				t.Errorf("StreamCSV() error = %#v, want a ParseError of kind %s at row %d", err, tt.wantKind, tt.wantRow)
+			}
+		})
+	}
+}
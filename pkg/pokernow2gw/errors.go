@@ -0,0 +1,66 @@
+package pokernow2gw
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseErrorKind tells apart the problems a ParseError can report
+type ParseErrorKind string
+
+const (
+	// ParseErrorHeader is used when the CSV header is missing or is not "entry,at,order"
+	ParseErrorHeader ParseErrorKind = "bad_header"
+	// ParseErrorRow is used for CSV rows that cannot be read, have the wrong number of columns or a bad order
+	ParseErrorRow ParseErrorKind = "bad_row"
+	// ParseErrorTimestamp is used for CSV rows whose timestamp is not RFC 3339
+	ParseErrorTimestamp ParseErrorKind = "bad_timestamp"
+	// ParseErrorAmount is used for chip amounts (stacks, blinds, antes, bets, ...) that are not numbers
+	ParseErrorAmount ParseErrorKind = "bad_amount"
+	// ParseErrorJSON is used for OHH input that is not valid JSON
+	ParseErrorJSON ParseErrorKind = "bad_json"
+	// ParseErrorUnsupportedGame is used for OHH hands of a game or bet limit that cannot be converted
+	ParseErrorUnsupportedGame ParseErrorKind = "unsupported_game"
+	// ParseErrorInvalidHand is used for OHH hands that cannot be converted for other reasons
+	// (too many players, unknown actions, filtered out by the player count)
+	ParseErrorInvalidHand ParseErrorKind = "invalid_hand"
+)
+
+// ParseError is an error in the input, with where it was found
+// Use errors.As to get it from the errors returned by the readers and Parse.
+// Position fields that do not apply to the input are left zero.
+type ParseError struct {
+	Kind       ParseErrorKind
+	Row        int    // CSV の行番号（ヘッダーが1行目）
+	Order      int64  // PokerNow ログの order 列
+	Line       int    // JSONL の行番号（1始まり）
+	HandNumber string // ハンド番号
+	Err        error  // 詳細
+}
+
+// Error returns the position followed by the underlying error
+func (e *ParseError) Error() string {
+	var position []string
+	if e.Line > 0 {
+		position = append(position, "line "+strconv.Itoa(e.Line))
+	}
+	if e.Row > 0 {
+		position = append(position, "row "+strconv.Itoa(e.Row))
+	}
+	if e.Order != 0 {
+		position = append(position, "order "+strconv.FormatInt(e.Order, 10))
+	}
+	if e.HandNumber != "" {
+		position = append(position, "hand #"+e.HandNumber)
+	}
+	if len(position) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", strings.Join(position, ", "), e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
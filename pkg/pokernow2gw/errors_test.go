@@ -0,0 +1,95 @@
+package pokernow2gw
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseError_Error(t *testing.T) {
+	err := errors.New("boom")
+	tests := []struct {
+		name string
+		err  *ParseError
+		want string
+	}{
+		{name: "no position", err: &ParseError{Kind: ParseErrorJSON, Err: err}, want: "boom"},
+		{name: "CSV row", err: &ParseError{Kind: ParseErrorTimestamp, Row: 3, Order: 176317560834401, Err: err}, want: "row 3, order 176317560834401: boom"},
+		{name: "hand", err: &ParseError{Kind: ParseErrorAmount, Order: 12, HandNumber: "7", Err: err}, want: "order 12, hand #7: boom"},
+		{name: "JSONL line", err: &ParseError{Kind: ParseErrorUnsupportedGame, Line: 2, HandNumber: "42", Err: err}, want: "line 2, hand #42: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if !errors.Is(tt.err, err) {
+				t.Error("ParseError should unwrap to the underlying error")
+			}
+		})
+	}
+}
+
+func TestParse_ParseErrors(t *testing.T) {
+	ohhSpec := func(gameType string) string {
+		return `{"ohh":{"game_type":"` + gameType + `","game_number":"42","bet_limit":{"bet_type":"NL"},"hero_player_id":1,` +
+			`"players":[{"id":1,"name":"Hero","seat":1,"starting_stack":100,"cards":["Ah","Kh"]}]}}`
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		format InputFormat
+		want   ParseError
+	}{
+		{
+			name: "amount that is not a number",
+			input: `entry,at,order
+"""player1 @ id1"" posts an ante of 1` + strings.Repeat("0", 400) + `",2025-11-15T05:09:14.567Z,2
+"-- starting hand #3 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,1`,
+			format: InputFormatPokerNowCSV,
+			want:   ParseError{Kind: ParseErrorAmount, Order: 2, HandNumber: "3"},
+		},
+		{
+			name:   "bad OHH JSON",
+			input:  `{"ohh": [}`,
+			format: InputFormatOHH,
+			want:   ParseError{Kind: ParseErrorJSON},
+		},
+		{
+			name:   "unsupported OHH game",
+			input:  ohhSpec("Stud"),
+			format: InputFormatOHH,
+			want:   ParseError{Kind: ParseErrorUnsupportedGame, HandNumber: "42"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input), ConvertOptions{HeroName: "Hero", InputFormat: tt.format})
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse() error = %v, want a ParseError", err)
+			}
+			if parseErr.Kind != tt.want.Kind || parseErr.Order != tt.want.Order || parseErr.HandNumber != tt.want.HandNumber {
+				t.Errorf("Parse() error = %+v, want kind %s, order %d, hand #%s", parseErr, tt.want.Kind, tt.want.Order, tt.want.HandNumber)
+			}
+		})
+	}
+
+	t.Run("JSONL line is skipped with its line number", func(t *testing.T) {
+		input := "\n" + ohhSpec("Holdem") + "\n" + ohhSpec("Stud") + "\n"
+		result, err := Parse(strings.NewReader(input), ConvertOptions{HeroName: "Hero", InputFormat: InputFormatOHHJSONL})
+		if err != nil {
+			t.Fatalf("Parse() failed: %v", err)
+		}
+		if len(result.SkippedHandsInfo) != 1 {
+			t.Fatalf("SkippedHandsInfo = %+v, want the Stud hand", result.SkippedHandsInfo)
+		}
+		info := result.SkippedHandsInfo[0]
+		if info.Reason != SkipReasonInvalidInput || !strings.HasPrefix(info.Detail, "line 3, hand #42: unsupported game") {
+			t.Errorf("skipped hand = %+v, want invalid_input at line 3, hand #42", info)
+		}
+	})
+}
@@ -10,41 +10,66 @@ import (
 
 // ConvertEntries converts LogEntry slice to GTO Wizard HH format
 func ConvertEntries(entries []LogEntry, opts ConvertOptions) (*ConvertResult, error) {
-	if len(entries) == 0 {
-		return &ConvertResult{
-			HH:           nil,
-			SkippedHands: 0,
-		}, nil
+	result, err := readEntryStream(func(fn func(LogEntry) error) error {
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, opts)
+	if err != nil {
+		return nil, err
 	}
+	return convertReadResult(result, opts)
+}
+
+// readEntryStream parses log entries produced by stream (in chronological order) into hands
+// Entries are parsed hand by hand as they arrive, so the whole log never has to be held in memory.
+func readEntryStream(stream func(fn func(LogEntry) error) error, opts ConvertOptions) (*ReadResult, error) {
+	parser := newHandParser(opts)
+	ledger := newLedgerBuilder()
+	var first *LogEntry
 
-	// Set defaults
-	if opts.SiteName == "" {
-		opts.SiteName = "PokerStars"
+	err := stream(func(entry LogEntry) error {
+		if first == nil {
+			first = &entry
+		}
+		ledger.observe(entry)
+		return parser.observe(entry)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if opts.TimeLocation == nil {
-		opts.TimeLocation = entries[0].At.Location()
+
+	if first == nil {
+		return &ReadResult{}, nil
 	}
 
 	// Parse hands
-	hands, skippedHands, skippedHandsInfo, err := ParseHands(entries, opts)
+	hands, skippedHands, skippedHandsInfo, err := parser.finish()
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to HH format
-	hh := convertHandsToHH(hands, opts)
-
-	return &ConvertResult{
-		HH:               []byte(hh),
+	return &ReadResult{
+		Hands:            hands,
 		SkippedHands:     skippedHands,
 		SkippedHandsInfo: skippedHandsInfo,
+		Ledger:           ledger.ledger(),
+		TimeLocation:     first.At.Location(),
+		Diagnostics:      parser.diagnostics,
+		Warnings:         parser.warnings,
+		checks:           parser.checks,
 	}, nil
 }
 
 // formatNumber formats a float64 amount as a string
 // Integers are formatted without decimal places (1.0 → "1")
 // Decimals are formatted with 2 decimal places (0.5 → "0.50")
+// The amount is rounded to cents first so that float drift (0.1+0.2) never leaks into the output
 func formatNumber(amount float64) string {
+	amount = roundAmount(amount)
 	if math.Trunc(amount) == amount {
 		// It's an integer
 		return strconv.FormatFloat(amount, 'f', 0, 64)
@@ -102,8 +127,60 @@ func formatAmount(amount float64, opts ConvertOptions, currency string) string {
 	return formatNumber(amount)
 }
 
-// convertHandsToHH converts Hand slice to GTO Wizard HH text
-func convertHandsToHH(hands []Hand, opts ConvertOptions) string {
+// gameLabel returns the PokerStars game label for a hand
+// Example: "Hold'em No Limit", "Omaha Pot Limit", "5 Card Omaha Pot Limit"
+func gameLabel(hand Hand) string {
+	game := "Hold'em"
+	switch hand.Game {
+	case PokerGameOmaha:
+		game = "Omaha"
+	case PokerGameOmaha5:
+		game = "5 Card Omaha"
+	case PokerGameOmaha6:
+		game = "6 Card Omaha"
+	}
+
+	limit := "No Limit"
+	if hand.Limit == BetLimitPotLimit {
+		limit = "Pot Limit"
+	}
+
+	return game + " " + limit
+}
+
+// hhDialect is the wording of a site's hand history text
+// Hands are written as PokerStars hand histories (pokerStarsDialect); other sites whose text GTO Wizard
+// reads in the same layout change only the parts that differ (see ggPokerDialect).
+type hhDialect struct {
+	// header returns the first line of a hand
+	header func(hand Hand, opts ConvertOptions, tournamentID, timestamp string) string
+	// dealAfterPosts prints the hole cards after the forced posts, with a line for every seat
+	dealAfterPosts bool
+	// showdownLabel names the showdown sections
+	showdownLabel string
+	// alwaysShowdown opens a showdown section whenever the pot is collected, instead of writing
+	// "doesn't show hand" for winners who did not show
+	alwaysShowdown bool
+	// anteFormat is the ante line, given the player and the amount
+	anteFormat string
+	// raiseIncrementAlways writes "raises X to Y" in tournaments too, not only in cash games
+	raiseIncrementAlways bool
+	// summaryWon is the summary wording for a winner who did not show
+	summaryWon string
+	// extraFees are listed after the rake in the summary, even when they are zero
+	extraFees []string
+}
+
+// pokerStarsDialect is the wording of PokerStars hand histories
+var pokerStarsDialect = hhDialect{
+	header:        pokerStarsHeader,
+	showdownLabel: "SHOW DOWN",
+	anteFormat:    "%s: posts an ante of %s\n",
+	summaryWon:    "collected",
+}
+
+// convertHandsToHH converts Hand slice to GTO Wizard HH text in the dialect d
+func convertHandsToHH(hands []Hand, opts ConvertOptions, d hhDialect) string {
 	var sb strings.Builder
 
 	// Determine tournament ID: use first hand's ID if not specified (only for tournaments)
@@ -119,35 +196,37 @@ func convertHandsToHH(hands []Hand, opts ConvertOptions) string {
 		if i > 0 {
 			sb.WriteString("\n\n")
 		}
-		sb.WriteString(convertHandToHH(hand, opts, tournamentID))
+		sb.WriteString(convertHandToHH(hand, opts, d, tournamentID))
 	}
 
 	return sb.String()
 }
 
-// convertHandToHH converts a single Hand to GTO Wizard HH text
-func convertHandToHH(hand Hand, opts ConvertOptions, tournamentID string) string {
-	var sb strings.Builder
-
-	// Hand header
-	timestamp := hand.StartTime.In(opts.TimeLocation).Format("2006/01/02 15:04:05")
-	timezoneLabel := getTimezoneLabel(opts.TimeLocation)
-
+// pokerStarsHeader returns the first line of a PokerStars hand history
+func pokerStarsHeader(hand Hand, opts ConvertOptions, tournamentID, timestamp string) string {
 	if opts.GameType == GameTypeCash {
 		// Cash game format: PokerStars Hand #ID: Hold'em No Limit ($SB/$BB USD) - timestamp TZ
 		// For Chips currency, omit $ and USD
+		timezoneLabel := getTimezoneLabel(opts.TimeLocation)
 		if isChipsCurrency(hand.Currency) {
-			sb.WriteString(fmt.Sprintf("%s Hand #%s: Hold'em No Limit (%s/%s) - %s %s\n",
-				opts.SiteName, hand.HandID, formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind), timestamp, timezoneLabel))
-		} else {
-			sb.WriteString(fmt.Sprintf("%s Hand #%s: Hold'em No Limit ($%s/$%s USD) - %s %s\n",
-				opts.SiteName, hand.HandID, formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind), timestamp, timezoneLabel))
+			return fmt.Sprintf("%s Hand #%s: %s (%s/%s) - %s %s\n",
+				opts.SiteName, hand.HandID, gameLabel(hand), formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind), timestamp, timezoneLabel)
 		}
-	} else {
-		// Tournament format
-		sb.WriteString(fmt.Sprintf("%s Hand #%s:  Tournament #%s, $0+$0 Hold'em No Limit - Level 1 (%s/%s) - %s\n",
-			opts.SiteName, hand.HandID, tournamentID, formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind), timestamp))
+		return fmt.Sprintf("%s Hand #%s: %s ($%s/$%s USD) - %s %s\n",
+			opts.SiteName, hand.HandID, gameLabel(hand), formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind), timestamp, timezoneLabel)
 	}
+	// Tournament format (level as a Roman numeral, e.g. "Level IV")
+	return fmt.Sprintf("%s Hand #%s:  Tournament #%s, $0+$0 %s - Level %s (%s/%s) - %s\n",
+		opts.SiteName, hand.HandID, tournamentID, gameLabel(hand), romanNumeral(hand.Level), formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind), timestamp)
+}
+
+// convertHandToHH converts a single Hand to GTO Wizard HH text in the dialect d
+func convertHandToHH(hand Hand, opts ConvertOptions, d hhDialect, tournamentID string) string {
+	var sb strings.Builder
+
+	// Hand header
+	timestamp := hand.StartTime.In(opts.TimeLocation).Format("2006/01/02 15:04:05")
+	sb.WriteString(d.header(hand, opts, tournamentID, timestamp))
 
 	// Table info
 	numPlayers := len(hand.Players)
@@ -184,25 +263,25 @@ func convertHandToHH(hand Hand, opts ConvertOptions, tournamentID string) string
 	}
 
 	// Actions by street
-	sb.WriteString("*** HOLE CARDS ***\n")
-	if len(hand.HeroCards) > 0 {
-		sb.WriteString(fmt.Sprintf("Dealt to %s [%s]\n", opts.HeroName, strings.Join(hand.HeroCards, " ")))
-	}
-	sb.WriteString(formatActionsForStreet(hand.Actions, StreetPreflop, hand, "", opts))
-	if len(hand.Board.Flop) > 0 {
-		flopStr := fmt.Sprintf("*** FLOP *** [%s]", strings.Join(hand.Board.Flop, " "))
-		sb.WriteString(formatActionsForStreet(hand.Actions, StreetFlop, hand, flopStr, opts))
-	}
-	if hand.Board.Turn != "" {
-		turnStr := fmt.Sprintf("*** TURN *** [%s] [%s]",
-			strings.Join(hand.Board.Flop, " "), hand.Board.Turn)
-		sb.WriteString(formatActionsForStreet(hand.Actions, StreetTurn, hand, turnStr, opts))
-	}
-	if hand.Board.River != "" {
-		riverStr := fmt.Sprintf("*** RIVER *** [%s %s] [%s]",
-			strings.Join(hand.Board.Flop, " "), hand.Board.Turn, hand.Board.River)
-		sb.WriteString(formatActionsForStreet(hand.Actions, StreetRiver, hand, riverStr, opts))
+	if d.dealAfterPosts {
+		// The forced posts come first, then a line is dealt to every seat
+		holeCards := []string{"*** HOLE CARDS ***"}
+		for _, player := range hand.Players {
+			if player.DisplayName == opts.HeroName && len(hand.HeroCards) > 0 {
+				holeCards = append(holeCards, fmt.Sprintf("Dealt to %s [%s]", player.DisplayName, strings.Join(hand.HeroCards, " ")))
+			} else {
+				holeCards = append(holeCards, fmt.Sprintf("Dealt to %s ", player.DisplayName))
+			}
+		}
+		sb.WriteString(formatActionsForStreet(hand.Actions, StreetPreflop, hand, strings.Join(holeCards, "\n"), opts, d))
+	} else {
+		sb.WriteString("*** HOLE CARDS ***\n")
+		if len(hand.HeroCards) > 0 {
+			sb.WriteString(fmt.Sprintf("Dealt to %s [%s]\n", opts.HeroName, strings.Join(hand.HeroCards, " ")))
+		}
+		sb.WriteString(formatActionsForStreet(hand.Actions, StreetPreflop, hand, "", opts, d))
 	}
+	sb.WriteString(formatBoardStreets(hand, opts, d))
 
 	// Showdown
 	hasShowdown := false
@@ -213,64 +292,156 @@ func convertHandToHH(hand Hand, opts ConvertOptions, tournamentID string) string
 		}
 	}
 
-	if hasShowdown {
-		sb.WriteString("*** SHOW DOWN ***\n")
+	runCount := len(hand.Board.Runs) + 1
+	showdownLabel := d.showdownLabel
+	if hasShowdown || (d.alwaysShowdown && len(hand.Winners) > 0) {
+		if runCount > 1 {
+			sb.WriteString(fmt.Sprintf("*** %s %s ***\n", runOrdinal(0), showdownLabel))
+		} else {
+			sb.WriteString(fmt.Sprintf("*** %s ***\n", showdownLabel))
+		}
 		for _, action := range hand.Actions {
 			if action.ActionType == ActionShow {
-				// Find winner to get hand cards
-				for _, winner := range hand.Winners {
-					if winner.Player == action.Player && len(winner.HandCards) > 0 {
-						sb.WriteString(fmt.Sprintf("%s: shows [%s]\n",
-							action.Player, strings.Join(winner.HandCards, " ")))
-						break
-					}
+				// Find winner to get hand cards (and the made hand if the player won)
+				cards, handName := shownHand(hand, action.playerKey())
+				if len(cards) == 0 {
+					continue
+				}
+				if handName != "" {
+					sb.WriteString(fmt.Sprintf("%s: shows [%s] (%s)\n",
+						action.Player, strings.Join(cards, " "), handName))
+				} else {
+					sb.WriteString(fmt.Sprintf("%s: shows [%s]\n",
+						action.Player, strings.Join(cards, " ")))
 				}
 			}
 		}
 	}
 
 	// "doesn't show hand" for winners without showdown
-	if !hasShowdown && len(hand.Winners) > 0 {
+	if !hasShowdown && !d.alwaysShowdown && len(hand.Winners) > 0 {
+		announced := make(map[string]bool)
 		for _, winner := range hand.Winners {
-			if winner.Amount > 0 {
+			if winner.Amount > 0 && !announced[winner.playerKey()] {
+				announced[winner.playerKey()] = true
 				sb.WriteString(fmt.Sprintf("%s: doesn't show hand\n", winner.Player))
 			}
 		}
 	}
 
+	// Main and side pots, only used when they add up to what was collected
+	totalPot := calculateTotalPot(hand)
+	pots := buildPots(hand)
+	if len(pots) < 2 || potTotal(pots) != totalPot {
+		pots = nil
+	}
+	winnerPots := assignWinnerPots(hand, pots)
+
 	// Output "collected from pot" line before SUMMARY
-	for _, winner := range hand.Winners {
-		if winner.Amount > 0 {
-			sb.WriteString(fmt.Sprintf("%s collected %s from pot\n", winner.Player, formatAmount(winner.Amount, opts, hand.Currency)))
+	// Each additional run of the board gets its own show down section
+	for run := 0; run < runCount; run++ {
+		if run > 0 {
+			sb.WriteString(fmt.Sprintf("*** %s %s ***\n", runOrdinal(run), showdownLabel))
+		}
+		for i, winner := range hand.Winners {
+			if winner.Amount > 0 && winner.Run == run {
+				source := "pot"
+				if winnerPots != nil {
+					source = potLabel(winnerPots[i], len(pots))
+				}
+				sb.WriteString(fmt.Sprintf("%s collected %s from %s\n", winner.Player, formatAmount(winner.Amount, opts, hand.Currency), source))
+			}
 		}
 	}
 
 	// Summary
 	sb.WriteString("*** SUMMARY ***\n")
-	totalPot := calculateTotalPot(hand)
 	rake := calculateRake(totalPot, hand.BigBlind, opts.RakePercent, opts.RakeCapBB)
-	sb.WriteString(fmt.Sprintf("Total pot %s | Rake %s\n", formatAmount(totalPot, opts, hand.Currency), formatAmount(rake, opts, hand.Currency)))
-	if len(hand.Board.Flop) > 0 {
-		boardCards := append([]string{}, hand.Board.Flop...)
-		if hand.Board.Turn != "" {
-			boardCards = append(boardCards, hand.Board.Turn)
-		}
-		if hand.Board.River != "" {
-			boardCards = append(boardCards, hand.Board.River)
+	sb.WriteString(fmt.Sprintf("Total pot %s ", formatAmount(totalPot, opts, hand.Currency)))
+	for i, p := range pots {
+		// PokerStars: "Total pot 900 Main pot 600. Side pot-1 200. Side pot-2 100. | Rake 0"
+		label := potLabel(i, len(pots))
+		sb.WriteString(fmt.Sprintf("%s%s %s. ", strings.ToUpper(label[:1]), label[1:], formatAmount(p.Amount, opts, hand.Currency)))
+	}
+	sb.WriteString(fmt.Sprintf("| Rake %s", formatAmount(rake, opts, hand.Currency)))
+	for _, fee := range d.extraFees {
+		sb.WriteString(fmt.Sprintf(" | %s %s", fee, formatAmount(0, opts, hand.Currency)))
+	}
+	sb.WriteString("\n")
+	if runCount > 1 {
+		sb.WriteString(fmt.Sprintf("Hand was run %s\n", runCountText(runCount)))
+		for i, run := range hand.Board.allRuns() {
+			sb.WriteString(fmt.Sprintf("%s Board [%s]\n", runOrdinal(i), strings.Join(run.cards(), " ")))
 		}
-		sb.WriteString(fmt.Sprintf("Board [%s]\n", strings.Join(boardCards, " ")))
+	} else if len(hand.Board.Flop) > 0 {
+		sb.WriteString(fmt.Sprintf("Board [%s]\n", strings.Join(hand.Board.allRuns()[0].cards(), " ")))
 	}
 
 	// Detailed player summary
 	for _, player := range hand.Players {
-		sb.WriteString(formatPlayerSummary(hand, player, opts))
+		sb.WriteString(formatPlayerSummary(hand, player, opts, d))
+	}
+
+	return sb.String()
+}
+
+// isForcedPost reports whether the action is a blind, ante or straddle posted before the cards are dealt
+func isForcedPost(actionType ActionType) bool {
+	switch actionType {
+	case ActionPostSB, ActionPostBB, ActionPostAnte, ActionPostStraddle, ActionPostDeadSB, ActionPostDeadBB:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatBoardStreets formats the flop, turn and river sections
+// When the board was run more than once, the streets dealt after the split are printed
+// once per run with PokerStars' "*** FIRST FLOP ***" / "*** SECOND FLOP ***" headers
+func formatBoardStreets(hand Hand, opts ConvertOptions, d hhDialect) string {
+	var sb strings.Builder
+	runs := hand.Board.allRuns()
+	flopShared, turnShared, riverShared := sharedStreets(runs)
+	first := runs[0]
+
+	if len(first.Flop) > 0 && flopShared {
+		flopStr := fmt.Sprintf("*** FLOP *** [%s]", strings.Join(first.Flop, " "))
+		sb.WriteString(formatActionsForStreet(hand.Actions, StreetFlop, hand, flopStr, opts, d))
+	}
+	if first.Turn != "" && turnShared {
+		turnStr := fmt.Sprintf("*** TURN *** [%s] [%s]",
+			strings.Join(first.Flop, " "), first.Turn)
+		sb.WriteString(formatActionsForStreet(hand.Actions, StreetTurn, hand, turnStr, opts, d))
+	}
+	if first.River != "" && riverShared {
+		riverStr := fmt.Sprintf("*** RIVER *** [%s %s] [%s]",
+			strings.Join(first.Flop, " "), first.Turn, first.River)
+		sb.WriteString(formatActionsForStreet(hand.Actions, StreetRiver, hand, riverStr, opts, d))
+	}
+
+	if len(runs) == 1 {
+		return sb.String()
+	}
+
+	// Streets dealt after the board was split (no betting happens after the all-in)
+	for i, run := range runs {
+		ordinal := runOrdinal(i)
+		if !flopShared && len(run.Flop) > 0 {
+			sb.WriteString(fmt.Sprintf("*** %s FLOP *** [%s]\n", ordinal, strings.Join(run.Flop, " ")))
+		}
+		if !turnShared && run.Turn != "" {
+			sb.WriteString(fmt.Sprintf("*** %s TURN *** [%s] [%s]\n", ordinal, strings.Join(run.Flop, " "), run.Turn))
+		}
+		if !riverShared && run.River != "" {
+			sb.WriteString(fmt.Sprintf("*** %s RIVER *** [%s %s] [%s]\n", ordinal, strings.Join(run.Flop, " "), run.Turn, run.River))
+		}
 	}
 
 	return sb.String()
 }
 
 // formatActionsForStreet formats actions for a specific street
-func formatActionsForStreet(actions []Action, street Street, hand Hand, streetHeader string, opts ConvertOptions) string {
+func formatActionsForStreet(actions []Action, street Street, hand Hand, streetHeader string, opts ConvertOptions, d hhDialect) string {
 	var sb strings.Builder
 	var streetActions []Action
 
@@ -284,79 +455,105 @@ func formatActionsForStreet(actions []Action, street Street, hand Hand, streetHe
 		return ""
 	}
 
-	if streetHeader != "" {
+	// Dialects that deal the hole cards after the forced posts hold the preflop header back for them
+	headerPending := streetHeader != ""
+	if headerPending && !(street == StreetPreflop && d.dealAfterPosts) {
 		sb.WriteString(streetHeader + "\n")
+		headerPending = false
 	}
 
-	// Track current bet amounts for each player and the highest bet in the street
-	playerBets := make(map[string]float64)
-	currentBet := 0.0
+	// The betting engine gives the exact call amounts, raise increments and all-ins
+	engine := newBettingEngine(hand)
+	for _, action := range actions {
+		if action.Street < street {
+			_, _ = engine.apply(action)
+		}
+	}
 
+	// Players who post both missing blinds are printed on one "posts small & big blinds" line
+	deadBB := make(map[string]float64)
 	for _, action := range streetActions {
+		if action.ActionType == ActionPostDeadBB {
+			deadBB[action.playerKey()] += action.Amount
+		}
+	}
+	postedBoth := make(map[string]bool)
+	for _, action := range streetActions {
+		if action.ActionType == ActionPostDeadSB && deadBB[action.playerKey()] > 0 {
+			postedBoth[action.playerKey()] = true
+		}
+	}
+
+	for _, action := range streetActions {
+		if headerPending && !isForcedPost(action.ActionType) {
+			sb.WriteString(streetHeader + "\n")
+			headerPending = false
+		}
+		step, err := engine.apply(action)
+		if err != nil {
+			// Hands whose chips do not add up may not replay; their amounts are written as logged
+			step = bettingStep{Added: action.Amount, RaiseBy: action.Amount, To: action.Amount, AllIn: action.IsAllIn}
+		}
 		switch action.ActionType {
 		case ActionPostSB:
 			sb.WriteString(fmt.Sprintf("%s: posts small blind %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
-			playerBets[action.Player] = action.Amount
-			if action.Amount > currentBet {
-				currentBet = action.Amount
-			}
 		case ActionPostBB:
 			sb.WriteString(fmt.Sprintf("%s: posts big blind %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
-			playerBets[action.Player] = action.Amount
-			if action.Amount > currentBet {
-				currentBet = action.Amount
+		case ActionPostStraddle:
+			// A straddle is a live blind: it counts as the player's bet and sets the amount to call
+			sb.WriteString(fmt.Sprintf("%s: posts straddle %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
+		case ActionPostDeadSB:
+			// The missing small blind is dead money and does not count toward the player's bet
+			if postedBoth[action.playerKey()] {
+				sb.WriteString(fmt.Sprintf("%s: posts small & big blinds %s\n", action.Player, formatAmount(action.Amount+deadBB[action.playerKey()], opts, hand.Currency)))
+			} else {
+				sb.WriteString(fmt.Sprintf("%s: posts small blind %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
+			}
+		case ActionPostDeadBB:
+			// The missing big blind is live
+			if !postedBoth[action.playerKey()] {
+				sb.WriteString(fmt.Sprintf("%s: posts big blind %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
 			}
 		case ActionPostAnte:
-			sb.WriteString(fmt.Sprintf("%s: posts an ante of %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
+			sb.WriteString(fmt.Sprintf(d.anteFormat, action.Player, formatAmount(action.Amount, opts, hand.Currency)))
 		case ActionFold:
 			sb.WriteString(fmt.Sprintf("%s: folds\n", action.Player))
 		case ActionCheck:
 			sb.WriteString(fmt.Sprintf("%s: checks\n", action.Player))
 		case ActionCall:
-			// Calculate the actual call amount (difference from current bet)
-			alreadyBet := playerBets[action.Player]
-			callAmount := currentBet - alreadyBet
-			if action.IsAllIn {
-				sb.WriteString(fmt.Sprintf("%s: calls %s and is all-in\n", action.Player, formatAmount(callAmount, opts, hand.Currency)))
+			if step.AllIn {
+				sb.WriteString(fmt.Sprintf("%s: calls %s and is all-in\n", action.Player, formatAmount(step.Added, opts, hand.Currency)))
 			} else {
-				sb.WriteString(fmt.Sprintf("%s: calls %s\n", action.Player, formatAmount(callAmount, opts, hand.Currency)))
+				sb.WriteString(fmt.Sprintf("%s: calls %s\n", action.Player, formatAmount(step.Added, opts, hand.Currency)))
 			}
-			playerBets[action.Player] = currentBet
 		case ActionBet:
-			if action.IsAllIn {
+			if step.AllIn {
 				sb.WriteString(fmt.Sprintf("%s: bets %s and is all-in\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
 			} else {
 				sb.WriteString(fmt.Sprintf("%s: bets %s\n", action.Player, formatAmount(action.Amount, opts, hand.Currency)))
 			}
-			playerBets[action.Player] = action.Amount
-			currentBet = action.Amount
 		case ActionRaise:
-			raiseAmount := action.Amount
-			// For all-in, add ante back to get the actual stack committed
-			if action.IsAllIn {
-				raiseAmount = action.Amount + hand.Ante
-			}
-			// For cash games, use "raises X to Y" format
-			if opts.GameType == GameTypeCash {
-				raiseDiff := raiseAmount - currentBet
-				if action.IsAllIn {
-					sb.WriteString(fmt.Sprintf("%s: raises %s to %s and is all-in\n", action.Player, formatAmount(raiseDiff, opts, hand.Currency), formatAmount(raiseAmount, opts, hand.Currency)))
+			// For cash games (and always in some dialects), use "raises X to Y" format
+			if opts.GameType == GameTypeCash || d.raiseIncrementAlways {
+				if step.AllIn {
+					sb.WriteString(fmt.Sprintf("%s: raises %s to %s and is all-in\n", action.Player, formatAmount(step.RaiseBy, opts, hand.Currency), formatAmount(action.Amount, opts, hand.Currency)))
 				} else {
-					sb.WriteString(fmt.Sprintf("%s: raises %s to %s\n", action.Player, formatAmount(raiseDiff, opts, hand.Currency), formatAmount(raiseAmount, opts, hand.Currency)))
+					sb.WriteString(fmt.Sprintf("%s: raises %s to %s\n", action.Player, formatAmount(step.RaiseBy, opts, hand.Currency), formatAmount(action.Amount, opts, hand.Currency)))
 				}
 			} else {
-				if action.IsAllIn {
-					sb.WriteString(fmt.Sprintf("%s: raises to %s and is all-in\n", action.Player, formatNumber(raiseAmount)))
+				if step.AllIn {
+					sb.WriteString(fmt.Sprintf("%s: raises to %s and is all-in\n", action.Player, formatNumber(action.Amount)))
 				} else {
-					sb.WriteString(fmt.Sprintf("%s: raises to %s\n", action.Player, formatNumber(raiseAmount)))
+					sb.WriteString(fmt.Sprintf("%s: raises to %s\n", action.Player, formatNumber(action.Amount)))
 				}
 			}
-			playerBets[action.Player] = raiseAmount
-			currentBet = raiseAmount
 		case ActionUncalled:
 			sb.WriteString(fmt.Sprintf("Uncalled bet (%s) returned to %s\n", formatAmount(action.Amount, opts, hand.Currency), action.Player))
 		}
 	}
+	if headerPending {
+		sb.WriteString(streetHeader + "\n")
+	}
 
 	return sb.String()
 }
@@ -364,7 +561,7 @@ func formatActionsForStreet(actions []Action, street Street, hand Hand, streetHe
 // getDealerSeat returns the seat number of the dealer
 func getDealerSeat(hand Hand) int {
 	for _, player := range hand.Players {
-		if player.DisplayName == hand.Dealer {
+		if player.key() == hand.dealerKey() {
 			return player.SeatNumber
 		}
 	}
@@ -372,7 +569,7 @@ func getDealerSeat(hand Hand) int {
 }
 
 // formatPlayerSummary formats a player's summary line
-func formatPlayerSummary(hand Hand, player Player, opts ConvertOptions) string {
+func formatPlayerSummary(hand Hand, player Player, opts ConvertOptions, d hhDialect) string {
 	var sb strings.Builder
 
 	// Determine player role
@@ -387,16 +584,16 @@ func formatPlayerSummary(hand Hand, player Player, opts ConvertOptions) string {
 	bbPlayer := ""
 	for _, action := range hand.Actions {
 		if action.ActionType == ActionPostSB {
-			sbPlayer = action.Player
+			sbPlayer = action.playerKey()
 		}
 		if action.ActionType == ActionPostBB {
-			bbPlayer = action.Player
+			bbPlayer = action.playerKey()
 		}
 	}
 
-	if player.DisplayName == sbPlayer {
+	if player.key() == sbPlayer {
 		role = " (small blind)"
-	} else if player.DisplayName == bbPlayer {
+	} else if player.key() == bbPlayer {
 		role = " (big blind)"
 	}
 
@@ -406,7 +603,7 @@ func formatPlayerSummary(hand Hand, player Player, opts ConvertOptions) string {
 	didBet := false
 
 	for _, action := range hand.Actions {
-		if action.Player == player.DisplayName {
+		if action.playerKey() == player.key() {
 			switch action.ActionType {
 			case ActionFold:
 				lastAction = "folded"
@@ -415,37 +612,55 @@ func formatPlayerSummary(hand Hand, player Player, opts ConvertOptions) string {
 				// Only voluntary actions count as "bet"
 				didBet = true
 				lastStreet = action.Street
-			case ActionPostSB, ActionPostBB, ActionPostAnte:
+			case ActionPostSB, ActionPostBB, ActionPostAnte, ActionPostStraddle, ActionPostDeadSB, ActionPostDeadBB:
 				// These are forced, not voluntary
 			}
 		}
 	}
 
-	// Check if player won
+	// Check if player won (a player may collect from several pots or runs)
 	wonAmount := 0.0
 	for _, winner := range hand.Winners {
-		if winner.Player == player.DisplayName {
-			wonAmount = winner.Amount
-			break
+		if winner.playerKey() == player.key() {
+			wonAmount += winner.Amount
 		}
 	}
+	wonAmount = roundAmount(wonAmount)
 
 	// Check if player showed hand
 	showedHand := false
 	for _, action := range hand.Actions {
-		if action.ActionType == ActionShow && action.Player == player.DisplayName {
+		if action.ActionType == ActionShow && action.playerKey() == player.key() {
 			showedHand = true
 			break
 		}
 	}
 
+	// Check if the hand went to showdown at all
+	hasShowdown := false
+	for _, action := range hand.Actions {
+		if action.ActionType == ActionShow {
+			hasShowdown = true
+			break
+		}
+	}
+	cards, handName := shownHand(hand, player.key())
+
 	// Format the line
 	sb.WriteString(fmt.Sprintf("Seat %d: %s%s ", player.SeatNumber, player.DisplayName, role))
 
-	if wonAmount > 0 {
-		sb.WriteString(fmt.Sprintf("collected (%s)", formatAmount(wonAmount, opts, hand.Currency)))
-	} else if showedHand {
+	if wonAmount > 0 && showedHand && len(cards) > 0 {
+		// PokerStars: "showed [Kh 9d] and won (120) with two pair, Kings and Nines"
+		sb.WriteString(fmt.Sprintf("showed [%s] and won (%s)", strings.Join(cards, " "), formatAmount(wonAmount, opts, hand.Currency)))
+		if handName != "" {
+			sb.WriteString(" with " + handName)
+		}
+	} else if wonAmount > 0 {
+		sb.WriteString(fmt.Sprintf("%s (%s)", d.summaryWon, formatAmount(wonAmount, opts, hand.Currency)))
+	} else if showedHand && len(cards) > 0 {
 		// Player showed hand but didn't win
+		sb.WriteString(fmt.Sprintf("showed [%s] and lost", strings.Join(cards, " ")))
+	} else if showedHand {
 		sb.WriteString("showed and lost")
 	} else if lastAction == "folded" {
 		streetName := streetToFoldString(lastStreet)
@@ -453,12 +668,50 @@ func formatPlayerSummary(hand Hand, player Player, opts ConvertOptions) string {
 		if !didBet {
 			sb.WriteString(" (didn't bet)")
 		}
+	} else if hasShowdown {
+		// Still in the hand at showdown without showing
+		sb.WriteString("mucked")
+		if player.DisplayName == opts.HeroName && len(hand.HeroCards) > 0 {
+			sb.WriteString(fmt.Sprintf(" [%s]", strings.Join(hand.HeroCards, " ")))
+		}
 	}
 
 	sb.WriteString("\n")
 	return sb.String()
 }
 
+// potLabel returns the PokerStars name of a pot: "main pot", "side pot" when there is only one
+// side pot, and "side pot-1", "side pot-2", ... otherwise
+func potLabel(index, potCount int) string {
+	switch {
+	case index == 0:
+		return "main pot"
+	case potCount == 2:
+		return "side pot"
+	default:
+		return fmt.Sprintf("side pot-%d", index)
+	}
+}
+
+// shownHand returns the cards a player (Player.key) showed and, if the player won, the made hand
+// A player can have several winner entries (side pots, runs of the board); the first ones found are used
+func shownHand(hand Hand, player string) ([]string, string) {
+	var cards []string
+	handName := ""
+	for _, winner := range hand.Winners {
+		if winner.playerKey() != player {
+			continue
+		}
+		if cards == nil && len(winner.HandCards) > 0 {
+			cards = winner.HandCards
+		}
+		if handName == "" && winner.Amount > 0 {
+			handName = winner.HandName
+		}
+	}
+	return cards, handName
+}
+
 // streetToFoldString converts street to fold description
 func streetToFoldString(street Street) string {
 	switch street {
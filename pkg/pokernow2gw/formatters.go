@@ -0,0 +1,115 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Formatter writes converted hands in one output format
+type Formatter interface {
+	Format(w io.Writer, hands []Hand, opts ConvertOptions) error
+}
+
+// FormatterFunc adapts an ordinary function to the Formatter interface
+type FormatterFunc func(w io.Writer, hands []Hand, opts ConvertOptions) error
+
+// Format calls f(w, hands, opts)
+func (f FormatterFunc) Format(w io.Writer, hands []Hand, opts ConvertOptions) error {
+	return f(w, hands, opts)
+}
+
+// DefaultOutputFormat is the formatter used when ConvertOptions.OutputFormat is empty
+const DefaultOutputFormat = OutputFormatPokerStars
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[OutputFormat]Formatter{
+		OutputFormatPokerStars: FormatterFunc(func(w io.Writer, hands []Hand, opts ConvertOptions) error {
+			return writeHH(w, hands, opts, pokerStarsDialect)
+		}),
+		OutputFormatGGPoker:   FormatterFunc(WriteGGPoker),
+		OutputFormatOHH:       FormatterFunc(WriteOHH),
+		OutputFormatOHHJSONL:  FormatterFunc(WriteOHHJSONL),
+		OutputFormatIPokerXML: FormatterFunc(WriteIPokerXML),
+	}
+)
+
+// RegisterFormatter makes a formatter available under name
+// It can be selected with ConvertOptions.OutputFormat, the CLI's --output-format flag or the WASM
+// parseCSV export. Registering a name twice, or a nil formatter, panics (as database/sql.Register does).
+func RegisterFormatter(name OutputFormat, formatter Formatter) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	if formatter == nil {
+		panic("pokernow2gw: RegisterFormatter formatter is nil")
+	}
+	if _, dup := formatters[name]; dup {
+		panic(fmt.Sprintf("pokernow2gw: RegisterFormatter called twice for %q", name))
+	}
+	formatters[name] = formatter
+}
+
+// LookupFormatter returns the formatter registered under name
+// An empty name returns the default (PokerStars) formatter.
+func LookupFormatter(name OutputFormat) (Formatter, error) {
+	if name == "" {
+		name = DefaultOutputFormat
+	}
+
+	formattersMu.RLock()
+	formatter, ok := formatters[name]
+	formattersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (supported: %s)", name, strings.Join(formatterNames(), ", "))
+	}
+	return formatter, nil
+}
+
+// Formatters returns the names of all registered formatters in alphabetical order
+func Formatters() []OutputFormat {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+
+	names := make([]OutputFormat, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// formatterNames returns the registered formatter names as strings
+func formatterNames() []string {
+	var names []string
+	for _, name := range Formatters() {
+		names = append(names, string(name))
+	}
+	return names
+}
+
+// formatHands writes hands with the formatter selected by opts.OutputFormat
+func formatHands(hands []Hand, opts ConvertOptions) ([]byte, error) {
+	formatter, err := LookupFormatter(opts.OutputFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, hands, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeHH writes hands as hand history text in the dialect d
+func writeHH(w io.Writer, hands []Hand, opts ConvertOptions, d hhDialect) error {
+	if _, err := io.WriteString(w, convertHandsToHH(hands, opts, d)); err != nil {
+		return fmt.Errorf("failed to write hand history: %w", err)
+	}
+	return nil
+}
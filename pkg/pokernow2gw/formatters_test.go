@@ -0,0 +1,91 @@
+package pokernow2gw
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLookupFormatter(t *testing.T) {
+	tests := []struct {
+		name    OutputFormat
+		wantErr bool
+	}{
+		{name: "", wantErr: false},
+		{name: OutputFormatPokerStars, wantErr: false},
+		{name: OutputFormatGGPoker, wantErr: false},
+		{name: OutputFormatOHH, wantErr: false},
+		{name: OutputFormatOHHJSONL, wantErr: false},
+		{name: OutputFormatIPokerXML, wantErr: false},
+		{name: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.name), func(t *testing.T) {
+			formatter, err := LookupFormatter(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupFormatter(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if !tt.wantErr && formatter == nil {
+				t.Errorf("LookupFormatter(%q) returned a nil formatter", tt.name)
+			}
+		})
+	}
+}
+
+func TestFormatHands_UnknownOutputFormat(t *testing.T) {
+	_, err := formatHands(nil, ConvertOptions{OutputFormat: "xml"})
+	if err == nil || !strings.Contains(err.Error(), `unknown output format "xml"`) || !strings.Contains(err.Error(), "pokerstars") {
+		t.Errorf("formatHands() error = %v, want unknown output format error listing the formatters", err)
+	}
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	const name OutputFormat = "test-hand-ids"
+	RegisterFormatter(name, FormatterFunc(func(w io.Writer, hands []Hand, opts ConvertOptions) error {
+		for _, hand := range hands {
+			if _, err := fmt.Fprintf(w, "%s %s\n", hand.HandID, opts.HeroName); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	defer func() {
+		formattersMu.Lock()
+		delete(formatters, name)
+		formattersMu.Unlock()
+	}()
+
+	if !slices.Contains(Formatters(), name) {
+		t.Errorf("Formatters() = %v, want it to contain %q", Formatters(), name)
+	}
+
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,7
+"""player1 @ id1"" collected 30 from pot",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" folds",2025-11-15T05:09:14.567Z,5
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,4
+"""player1 @ id1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,3
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,2
+"Player stacks: #1 ""player1 @ id1"" (1500) | #2 ""player2 @ id2"" (1500)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,0`
+
+	result, err := Parse(strings.NewReader(csv), ConvertOptions{HeroName: "player1", OutputFormat: name})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if got := string(result.HH); !strings.HasSuffix(got, " player1\n") {
+		t.Errorf("custom formatter output = %q", got)
+	}
+
+	t.Run("duplicate name panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("RegisterFormatter() with a registered name did not panic")
+			}
+		}()
+		RegisterFormatter(OutputFormatPokerStars, FormatterFunc(WriteGGPoker))
+	})
+}
@@ -0,0 +1,43 @@
+package pokernow2gw
+
+import (
+	"fmt"
+	"io"
+)
+
+// ggPokerDialect is the wording of GGPoker hand histories
+// GGPoker deals the hole cards after the forced posts, always opens a showdown section and lists its
+// extra fees after the rake.
+var ggPokerDialect = hhDialect{
+	header:               ggPokerHeader,
+	dealAfterPosts:       true,
+	showdownLabel:        "SHOWDOWN",
+	alwaysShowdown:       true,
+	anteFormat:           "%s: posts the ante %s\n",
+	raiseIncrementAlways: true,
+	summaryWon:           "won",
+	extraFees:            []string{"Jackpot", "Bingo", "Fortune", "Tax"},
+}
+
+// WriteGGPoker writes hands as GGPoker-style hand history text
+func WriteGGPoker(w io.Writer, hands []Hand, opts ConvertOptions) error {
+	return writeHH(w, hands, opts, ggPokerDialect)
+}
+
+// ggPokerHeader returns the first line of a GGPoker hand history
+// Example (cash): "Poker Hand #HD123: Hold'em No Limit ($1/$2) - 2025/11/15 05:09:14"
+// Example (tournament): "Poker Hand #TM123: Tournament #456, Hold'em No Limit - Level4(200/400(50)) - 2025/11/15 05:09:14"
+func ggPokerHeader(hand Hand, opts ConvertOptions, tournamentID, timestamp string) string {
+	if opts.GameType == GameTypeCash {
+		return fmt.Sprintf("Poker Hand #HD%s: %s (%s/%s) - %s\n",
+			hand.HandID, gameLabel(hand), formatAmount(hand.SmallBlind, opts, hand.Currency), formatAmount(hand.BigBlind, opts, hand.Currency), timestamp)
+	}
+
+	level := max(hand.Level, 1)
+	blinds := fmt.Sprintf("%s/%s", formatNumber(hand.SmallBlind), formatNumber(hand.BigBlind))
+	if hand.Ante > 0 {
+		blinds += fmt.Sprintf("(%s)", formatNumber(hand.Ante))
+	}
+	return fmt.Sprintf("Poker Hand #TM%s: Tournament #%s, %s - Level%d(%s) - %s\n",
+		hand.HandID, tournamentID, gameLabel(hand), level, blinds, timestamp)
+}
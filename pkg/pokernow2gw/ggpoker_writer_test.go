@@ -0,0 +1,44 @@
+package pokernow2gw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGGPokerHeader(t *testing.T) {
+	hand := Hand{HandID: "123", SmallBlind: 200, BigBlind: 400, Level: 4}
+
+	tests := []struct {
+		name string
+		opts ConvertOptions
+		ante float64
+		want string
+	}{
+		{
+			name: "cash",
+			opts: ConvertOptions{GameType: GameTypeCash, TimeLocation: time.UTC},
+			want: "Poker Hand #HD123: Hold'em No Limit ($200/$400) - 2025/11/15 05:09:14\n",
+		},
+		{
+			name: "tournament",
+			opts: ConvertOptions{GameType: GameTypeTournament, TimeLocation: time.UTC},
+			want: "Poker Hand #TM123: Tournament #456, Hold'em No Limit - Level4(200/400) - 2025/11/15 05:09:14\n",
+		},
+		{
+			name: "tournament with an ante",
+			opts: ConvertOptions{GameType: GameTypeTournament, TimeLocation: time.UTC},
+			ante: 50,
+			want: "Poker Hand #TM123: Tournament #456, Hold'em No Limit - Level4(200/400(50)) - 2025/11/15 05:09:14\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := hand
+			h.Ante = tt.ante
+			if got := ggPokerHeader(h, tt.opts, "456", "2025/11/15 05:09:14"); got != tt.want {
+				t.Errorf("ggPokerHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
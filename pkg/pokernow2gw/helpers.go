@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -67,6 +68,22 @@ func extractDisplayName(fullName string) string {
 	return displayName
 }
 
+// extractPlayerID extracts the PokerNow player ID from full name
+// Example: "whywaita @ DtjzvbAuKs" -> "DtjzvbAuKs"
+// Example: "spa @ ces @ ZQfm6ZDMPO" -> "ZQfm6ZDMPO"
+// Names without an ID fall back to the display name so they still have a stable key
+func extractPlayerID(fullName string) string {
+	lastAtIndex := strings.LastIndex(fullName, "@")
+	if lastAtIndex == -1 {
+		return extractDisplayName(fullName)
+	}
+	id := strings.TrimSpace(fullName[lastAtIndex+1:])
+	if id == "" {
+		return extractDisplayName(fullName)
+	}
+	return id
+}
+
 // normalizePlayerSeats renumbers player seats from 1 to N
 // This ensures compatibility with GTO Wizard which doesn't recognize button at seat 10
 func normalizePlayerSeats(players []Player) []Player {
@@ -91,6 +108,62 @@ func normalizePlayerSeats(players []Player) []Player {
 	return sortedPlayers
 }
 
+// seatsRenumberedWarning returns the warning for a hand whose seats normalizePlayerSeats changes
+// players are the players before normalization.
+func seatsRenumberedWarning(handID string, players []Player) (Warning, bool) {
+	seats := make([]int, 0, len(players))
+	for _, p := range players {
+		seats = append(seats, p.SeatNumber)
+	}
+	sort.Ints(seats)
+
+	renumbered := false
+	for i, seat := range seats {
+		if seat != i+1 {
+			renumbered = true
+			break
+		}
+	}
+	if !renumbered {
+		return Warning{}, false
+	}
+
+	seatNames := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		seatNames = append(seatNames, strconv.Itoa(seat))
+	}
+	return Warning{
+		Code:    WarningSeatsRenumbered,
+		HandID:  handID,
+		Message: fmt.Sprintf("seats %s were renumbered 1-%d", strings.Join(seatNames, ", "), len(seats)),
+	}, true
+}
+
+// handIDHashedWarning returns the warning for a hand whose ID convertHandIDToNumeric replaced by a hash
+func handIDHashedWarning(originalID, handID string) (Warning, bool) {
+	if originalID == handID {
+		return Warning{}, false
+	}
+	return Warning{
+		Code:    WarningHandIDHashed,
+		HandID:  handID,
+		Message: fmt.Sprintf("hand ID %q is not numeric and was replaced by a hash of it", originalID),
+	}, true
+}
+
+// normalizationWarnings returns the warnings for a hand whose seats were normalized and whose ID
+// originalID was converted to handID
+func normalizationWarnings(originalID, handID string, loggedPlayers []Player) []Warning {
+	var warnings []Warning
+	if w, ok := handIDHashedWarning(originalID, handID); ok {
+		warnings = append(warnings, w)
+	}
+	if w, ok := seatsRenumberedWarning(handID, loggedPlayers); ok {
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
 // convertHandIDToNumeric converts a string hand ID to a numeric string
 // This ensures compatibility with tools like GTO Wizard that expect numeric hand IDs
 func convertHandIDToNumeric(handID string) string {
@@ -107,6 +180,244 @@ func convertHandIDToNumeric(handID string) string {
 	return fmt.Sprintf("%d", numericID)
 }
 
+// parseAmount parses a PokerNow chip amount such as "100" or "0.25"
+// The result is rounded to cents so that decimal amounts do not accumulate float drift
+func parseAmount(s string) (float64, error) {
+	amount, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return roundAmount(amount), nil
+}
+
+// roundAmount rounds an amount to cents
+// Sums and differences of decimal amounts (e.g., 0.1 + 0.2) are passed through this
+// to remove binary floating point error before they are compared or printed
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// allRuns returns every run of the board, starting with the first run
+func (b Board) allRuns() []BoardRun {
+	runs := make([]BoardRun, 0, len(b.Runs)+1)
+	runs = append(runs, BoardRun{Flop: b.Flop, Turn: b.Turn, River: b.River})
+	return append(runs, b.Runs...)
+}
+
+// cards returns the board cards of a run in dealing order
+func (r BoardRun) cards() []string {
+	cards := append([]string{}, r.Flop...)
+	if r.Turn != "" {
+		cards = append(cards, r.Turn)
+	}
+	if r.River != "" {
+		cards = append(cards, r.River)
+	}
+	return cards
+}
+
+// sharedStreets reports whether the flop, turn and river are identical across all runs
+// A street is only shared if every earlier street is shared as well
+func sharedStreets(runs []BoardRun) (flop, turn, river bool) {
+	flop, turn, river = true, true, true
+	for _, run := range runs[1:] {
+		if strings.Join(run.Flop, " ") != strings.Join(runs[0].Flop, " ") {
+			flop = false
+		}
+		if run.Turn != runs[0].Turn {
+			turn = false
+		}
+		if run.River != runs[0].River {
+			river = false
+		}
+	}
+	turn = turn && flop
+	river = river && turn
+	return flop, turn, river
+}
+
+// runOrdinal returns the PokerStars ordinal label for a board run index
+// Example: 0 -> "FIRST", 1 -> "SECOND"
+func runOrdinal(index int) string {
+	ordinals := []string{"FIRST", "SECOND", "THIRD", "FOURTH", "FIFTH"}
+	if index >= 0 && index < len(ordinals) {
+		return ordinals[index]
+	}
+	return fmt.Sprintf("RUN %d", index+1)
+}
+
+// runIndexFromWord converts the run word in a PokerNow board entry to a run index
+// Example: "first" -> 0, "second" -> 1
+func runIndexFromWord(word string) (int, bool) {
+	switch strings.ToLower(word) {
+	case "first":
+		return 0, true
+	case "second":
+		return 1, true
+	case "third":
+		return 2, true
+	case "fourth":
+		return 3, true
+	case "fifth":
+		return 4, true
+	default:
+		return 0, false
+	}
+}
+
+// runCountText returns how many times the board was run, as PokerStars phrases it
+// Example: 2 -> "twice", 3 -> "three times"
+func runCountText(count int) string {
+	switch count {
+	case 2:
+		return "twice"
+	case 3:
+		return "three times"
+	case 4:
+		return "four times"
+	default:
+		return fmt.Sprintf("%d times", count)
+	}
+}
+
+// romanNumeral converts a tournament level number to the Roman numeral PokerStars prints in MTT headers
+// Example: 1 -> "I", 4 -> "IV", 14 -> "XIV". Values below 1 are treated as level 1.
+func romanNumeral(n int) string {
+	if n < 1 {
+		n = 1
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+
+	var sb strings.Builder
+	for i, v := range values {
+		for n >= v {
+			sb.WriteString(symbols[i])
+			n -= v
+		}
+	}
+	return sb.String()
+}
+
+// romanNumeralValue converts a Roman numeral from a PokerStars MTT header back to a level number
+// Example: "IV" -> 4. Returns 0 for an invalid numeral.
+func romanNumeralValue(s string) int {
+	values := map[byte]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		v, ok := values[s[i]]
+		if !ok {
+			return 0
+		}
+		if i+1 < len(s) && values[s[i+1]] > v {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+	return n
+}
+
+// rankNames holds the PokerStars names of card ranks, from deuce to ace
+var rankNames = []string{"Deuce", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"}
+
+// rankIndex parses a rank as written in PokerNow hand names into an index of rankNames
+// Example: "K's" -> 11, "10" -> 8, "Ah" (flush high card with suit) -> 12
+func rankIndex(token string) (int, bool) {
+	token = strings.TrimSpace(token)
+	token = strings.TrimSuffix(token, "'s")
+	token = strings.TrimSuffix(strings.TrimSuffix(token, " High"), " high")
+	token = convertCard(token)
+	if len(token) == 2 && strings.ContainsRune("hdcs", rune(token[1])) {
+		token = token[:1]
+	}
+	index := strings.Index("23456789TJQKA", token)
+	if len(token) != 1 || index == -1 {
+		return 0, false
+	}
+	return index, true
+}
+
+// rankPlural returns the plural PokerStars name of a rank (e.g., "Kings", "Sixes")
+func rankPlural(index int) string {
+	if rankNames[index] == "Six" {
+		return "Sixes"
+	}
+	return rankNames[index] + "s"
+}
+
+// straightText returns the "Low to High" description of a straight with the given high card
+func straightText(high int) string {
+	low := high - 4
+	if low < 0 {
+		// The wheel (A-2-3-4-5) is the only straight that can be this low
+		return "Ace to Five"
+	}
+	return rankNames[low] + " to " + rankNames[high]
+}
+
+// pokerStarsHandName converts a PokerNow made-hand description to the PokerStars wording
+// Example: "Two Pair, K's & 9's" -> "two pair, Kings and Nines"
+// Example: "Full House, 7's over K's" -> "a full house, Sevens full of Kings"
+// Example: "Straight, J High" -> "a straight, Seven to Jack"
+// Descriptions that cannot be recognised are returned unchanged
+func pokerStarsHandName(name string) string {
+	name = strings.TrimSpace(name)
+	category, detail, _ := strings.Cut(name, ", ")
+
+	switch strings.ToLower(category) {
+	case "high card":
+		if r, ok := rankIndex(detail); ok {
+			return "high card " + rankNames[r]
+		}
+	case "pair":
+		if r, ok := rankIndex(detail); ok {
+			return "a pair of " + rankPlural(r)
+		}
+	case "two pair":
+		high, low, found := strings.Cut(detail, " & ")
+		r1, ok1 := rankIndex(high)
+		r2, ok2 := rankIndex(low)
+		if found && ok1 && ok2 {
+			return "two pair, " + rankPlural(r1) + " and " + rankPlural(r2)
+		}
+	case "three of a kind":
+		if r, ok := rankIndex(detail); ok {
+			return "three of a kind, " + rankPlural(r)
+		}
+	case "straight":
+		if r, ok := rankIndex(detail); ok {
+			return "a straight, " + straightText(r)
+		}
+	case "flush":
+		if r, ok := rankIndex(detail); ok {
+			return "a flush, " + rankNames[r] + " high"
+		}
+	case "full house":
+		trips, pair, found := strings.Cut(detail, " over ")
+		r1, ok1 := rankIndex(trips)
+		r2, ok2 := rankIndex(pair)
+		if found && ok1 && ok2 {
+			return "a full house, " + rankPlural(r1) + " full of " + rankPlural(r2)
+		}
+	case "four of a kind":
+		if r, ok := rankIndex(detail); ok {
+			return "four of a kind, " + rankPlural(r)
+		}
+	case "straight flush":
+		if r, ok := rankIndex(detail); ok {
+			if r == len(rankNames)-1 {
+				return "a Royal Flush"
+			}
+			return "a straight flush, " + straightText(r)
+		}
+	case "royal flush":
+		return "a Royal Flush"
+	}
+
+	return name
+}
+
 // calculateTotalPot calculates the total pot
 func calculateTotalPot(hand Hand) float64 {
 	total := 0.0
@@ -115,7 +426,7 @@ func calculateTotalPot(hand Hand) float64 {
 	}
 	// Uncalled bets should NOT be added to the pot
 	// because they were returned to the player
-	return total
+	return roundAmount(total)
 }
 
 // calculateRake calculates the rake for a hand based on rake settings
@@ -136,5 +447,5 @@ func calculateRake(totalPot float64, bigBlind float64, rakePercent float64, rake
 		rake = rakeCap
 	}
 
-	return rake
+	return roundAmount(rake)
 }
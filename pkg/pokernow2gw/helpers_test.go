@@ -417,6 +417,40 @@ func TestNormalizePlayerSeats(t *testing.T) {
 	}
 }
 
+func TestSeatsRenumberedWarning(t *testing.T) {
+	tests := []struct {
+		name   string
+		seats  []int
+		want   Warning
+		wantOK bool
+	}{
+		{name: "already 1 to N", seats: []int{1, 2, 3}},
+		{name: "already 1 to N out of order", seats: []int{3, 1, 2}},
+		{
+			name:   "gaps",
+			seats:  []int{9, 2, 5},
+			want:   Warning{Code: WarningSeatsRenumbered, HandID: "42", Message: "seats 2, 5, 9 were renumbered 1-3"},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make([]Player, 0, len(tt.seats))
+			for _, seat := range tt.seats {
+				players = append(players, Player{SeatNumber: seat})
+			}
+			got, ok := seatsRenumberedWarning("42", players)
+			if ok != tt.wantOK {
+				t.Fatalf("seatsRenumberedWarning() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("seatsRenumberedWarning() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestIsJSONFormat(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -652,58 +686,84 @@ func TestGetDealerSeat(t *testing.T) {
 
 func TestGoldenFile(t *testing.T) {
 	inputPath := "../../sample/input/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.csv"
-	outputPath := "../../sample/output/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.txt"
 
-	// Skip if sample files don't exist
-	if _, err := os.Stat(inputPath); os.IsNotExist(err) {
-		t.Skip("Sample input file not found, skipping golden file test")
-	}
-	if _, err := os.Stat(outputPath); os.IsNotExist(err) {
-		t.Skip("Sample output file not found, skipping golden file test")
+	tests := []struct {
+		name         string
+		outputPath   string
+		outputFormat OutputFormat
+	}{
+		{
+			name:         "PokerStars",
+			outputPath:   "../../sample/output/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.txt",
+			outputFormat: OutputFormatPokerStars,
+		},
+		{
+			name:         "GGPoker",
+			outputPath:   "../../sample/output/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru-ggpoker.txt",
+			outputFormat: OutputFormatGGPoker,
+		},
 	}
 
-	// Read input
-	inputFile, err := os.Open(inputPath)
-	if err != nil {
-		t.Fatalf("Failed to open input file: %v", err)
-	}
-	defer inputFile.Close()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Skip if sample files don't exist
+			if _, err := os.Stat(inputPath); os.IsNotExist(err) {
+				t.Skip("Sample input file not found, skipping golden file test")
+			}
+			if _, err := os.Stat(tt.outputPath); os.IsNotExist(err) {
+				t.Skip("Sample output file not found, skipping golden file test")
+			}
 
-	// Read expected output
-	expectedBytes, err := os.ReadFile(outputPath)
-	if err != nil {
-		t.Fatalf("Failed to read expected output file: %v", err)
-	}
-	expected := string(expectedBytes)
+			// Read input
+			inputFile, err := os.Open(inputPath)
+			if err != nil {
+				t.Fatalf("Failed to open input file: %v", err)
+			}
+			defer inputFile.Close()
 
-	// Run conversion
-	opts := ConvertOptions{
-		HeroName:     "whywaita",
-		SiteName:     "PokerStars",
-		TimeLocation: time.UTC,
-	}
+			// Read expected output
+			expectedBytes, err := os.ReadFile(tt.outputPath)
+			if err != nil {
+				t.Fatalf("Failed to read expected output file: %v", err)
+			}
+			expected := string(expectedBytes)
+
+			// Run conversion
+			opts := ConvertOptions{
+				HeroName:     "whywaita",
+				SiteName:     "PokerStars",
+				TimeLocation: time.UTC,
+				OutputFormat: tt.outputFormat,
+			}
 
-	result, err := ParseCSV(inputFile, opts)
-	if err != nil {
-		t.Fatalf("ParseCSV() error: %v", err)
-	}
+			result, err := ParseCSV(inputFile, opts)
+			if err != nil {
+				t.Fatalf("ParseCSV() error: %v", err)
+			}
 
-	if result == nil {
-		t.Fatal("ParseCSV() returned nil result")
-	}
+			if result == nil {
+				t.Fatal("ParseCSV() returned nil result")
+			}
 
-	got := string(result.HH)
+			got := string(result.HH)
 
-	// Compare output
-	if diff := cmp.Diff(expected, got); diff != "" {
-		// Show a more helpful message with the first few lines of diff
-		lines := strings.Split(diff, "\n")
-		maxLines := 50
-		if len(lines) > maxLines {
-			lines = lines[:maxLines]
-		}
-		t.Errorf("Golden file mismatch (-expected +got):\n%s\n... (showing first %d lines of diff)",
-			strings.Join(lines, "\n"), maxLines)
+			// Compare output
+			if diff := cmp.Diff(expected, got); diff != "" {
+				// Show a more helpful message with the first few lines of diff
+				lines := strings.Split(diff, "\n")
+				maxLines := 50
+				if len(lines) > maxLines {
+					lines = lines[:maxLines]
+				}
+				t.Errorf("Golden file mismatch (-expected +got):\n%s\n... (showing first %d lines of diff)",
+					strings.Join(lines, "\n"), maxLines)
+			}
+
+			// The PokerStars output reads back into the same hand histories
+			if tt.outputFormat == OutputFormatPokerStars {
+				assertPokerStarsRoundTrip(t, got, opts)
+			}
+		})
 	}
 }
 
@@ -787,6 +847,16 @@ func TestFormatNumber(t *testing.T) {
 			amount: 0.25,
 			want:   "0.25",
 		},
+		{
+			name:   "float drift in decimal sum",
+			amount: 0.1 + 0.2,
+			want:   "0.30",
+		},
+		{
+			name:   "float drift around integer",
+			amount: 0.7 + 0.1 + 0.2,
+			want:   "1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -798,3 +868,139 @@ func TestFormatNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{
+			name:  "integer",
+			input: "100",
+			want:  100,
+		},
+		{
+			name:  "cents",
+			input: "0.25",
+			want:  0.25,
+		},
+		{
+			name:  "single decimal digit",
+			input: "0.1",
+			want:  0.1,
+		},
+		{
+			name:  "dollars and cents",
+			input: "12.34",
+			want:  12.34,
+		},
+		{
+			name:    "invalid",
+			input:   "abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAmount(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAmount(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAmount(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRomanNumeral(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want string
+	}{
+		{name: "level 1", n: 1, want: "I"},
+		{name: "level 4", n: 4, want: "IV"},
+		{name: "level 9", n: 9, want: "IX"},
+		{name: "level 14", n: 14, want: "XIV"},
+		{name: "level 40", n: 40, want: "XL"},
+		{name: "unknown level (0)", n: 0, want: "I"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := romanNumeral(tt.n)
+			if got != tt.want {
+				t.Errorf("romanNumeral(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPokerStarsHandName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "high card", in: "High Card, A", want: "high card Ace"},
+		{name: "pair", in: "Pair, J's", want: "a pair of Jacks"},
+		{name: "pair of sixes", in: "Pair, 6's", want: "a pair of Sixes"},
+		{name: "two pair", in: "Two Pair, K's & 9's", want: "two pair, Kings and Nines"},
+		{name: "two pair with tens", in: "Two Pair, A's & 10's", want: "two pair, Aces and Tens"},
+		{name: "three of a kind", in: "Three of a Kind, 5's", want: "three of a kind, Fives"},
+		{name: "straight", in: "Straight, J High", want: "a straight, Seven to Jack"},
+		{name: "wheel", in: "Straight, 5 High", want: "a straight, Ace to Five"},
+		{name: "flush with suited high card", in: "Flush, Ah High", want: "a flush, Ace high"},
+		{name: "full house", in: "Full House, 7's over K's", want: "a full house, Sevens full of Kings"},
+		{name: "four of a kind", in: "Four of a Kind, 2's", want: "four of a kind, Deuces"},
+		{name: "straight flush", in: "Straight Flush, 9 High", want: "a straight flush, Five to Nine"},
+		{name: "ace high straight flush", in: "Straight Flush, A High", want: "a Royal Flush"},
+		{name: "royal flush", in: "Royal Flush", want: "a Royal Flush"},
+		{name: "unknown category is passed through", in: "Five of a Kind, A's", want: "Five of a Kind, A's"},
+		{name: "unparsable rank is passed through", in: "Pair, X's", want: "Pair, X's"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pokerStarsHandName(tt.in)
+			if got != tt.want {
+				t.Errorf("pokerStarsHandName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectInputFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		complete bool
+		want     []InputFormat
+	}{
+		{name: "complete CSV", prefix: "entry,at,order\n", complete: true, want: []InputFormat{InputFormatPokerNowCSV}},
+		{name: "CSV with BOM", prefix: "\xef\xbb\xbfentry,at,order\r\n", complete: true, want: []InputFormat{InputFormatPokerNowCSV}},
+		{name: "complete JSON", prefix: `{"ohh":{}}`, complete: true, want: []InputFormat{InputFormatOHH}},
+		{name: "complete JSONL", prefix: "{\"ohh\":{}}\n{\"ohh\":{}}\n", complete: true, want: []InputFormat{InputFormatOHHJSONL}},
+		{name: "quoted CSV header", prefix: "\"entry\",\"at\",\"order\"\r\n", complete: true, want: []InputFormat{InputFormatPokerNowCSV}},
+		{name: "CSV header with an extra column", prefix: "entry,at,order,note\n", complete: true, want: nil},
+		{name: "padded CSV header", prefix: "entry, at, order\n", complete: true, want: nil},
+		{name: "CSV prefix", prefix: "entry,at,order\n\"-- starting hand", complete: false, want: []InputFormat{InputFormatPokerNowCSV}},
+		{name: "JSONL prefix cut mid-line", prefix: "{\"ohh\":{}}\n{\"ohh\":{\"ga", complete: false, want: []InputFormat{InputFormatOHHJSONL}},
+		{name: "pretty-printed JSON prefix", prefix: "{\n  \"ohh\": {\n    \"spec_version\"", complete: false, want: []InputFormat{InputFormatOHH}},
+		{name: "single-line JSON longer than the prefix", prefix: `{"hands":[{"id":"1"},{"id":`, complete: false, want: []InputFormat{InputFormatOHH}},
+		{name: "unknown text", prefix: "hello world\n", complete: true, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectInputFormat([]byte(tt.prefix), tt.complete)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("detectInputFormat(%q, %v) mismatch (-want +got):\n%s", tt.prefix, tt.complete, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package pokernow2gw
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// resolveHeroKey returns the Player.key of the hero, identified before the players are renamed
+// A given name is matched against the display names players used in the input, which have their
+// spaces removed ("why waita" is written as "whywaita"). When several player IDs used the name, the
+// one dealt the hero cards is picked as detectHero does. Without a name, the hero is detected from the
+// hands. Returns an empty key if no player used the name (see heroOutputName).
+func resolveHeroKey(name string, hands []Hand) (string, error) {
+	if name == "" {
+		return detectHero(hands, nil)
+	}
+	stripped := strings.ReplaceAll(name, " ", "")
+	for _, candidate := range []string{name, stripped} {
+		keys := playerKeysByName(hands, candidate)
+		switch len(keys) {
+		case 0:
+			continue
+		case 1:
+			return keys[0], nil
+		default:
+			return detectHero(hands, keys)
+		}
+	}
+	return "", nil
+}
+
+// playerKeysByName returns the Player.key of every player seated under the display name in any of the hands
+func playerKeysByName(hands []Hand, name string) []string {
+	var keys []string
+	for _, hand := range hands {
+		for _, player := range hand.Players {
+			if player.DisplayName == name && !slices.Contains(keys, player.key()) {
+				keys = append(keys, player.key())
+			}
+		}
+	}
+	return keys
+}
+
+// heroOutputName returns the name the hero is written with, and the Player.key of the hero
+// It is called once the players are renamed. A hero found by resolveHeroKey gets their output name;
+// otherwise the name is looked up among the output names (an alias or a player ID given as the
+// hero name), and kept as given if no player is written under it.
+func heroOutputName(hands []Hand, key, name string) (string, string) {
+	for _, hand := range hands {
+		for _, player := range hand.Players {
+			if key != "" && player.key() == key {
+				return player.DisplayName, key
+			}
+			if key == "" && player.DisplayName == name {
+				return name, player.key()
+			}
+		}
+	}
+	return name, key
+}
+
+// detectHero infers the Player.key of the hero from the hands in which hero cards were dealt
+// A player who shows exactly the hero cards is the hero, and a player who shows other cards is not.
+// Otherwise the hero is the only player seated in every hand with hero cards; when several players
+// are, those who played a hand without hero cards (the hero was not dealt in) are ruled out.
+// Only the players in among are considered, unless it is nil.
+// Returns an empty key if no hand has hero cards, and ErrHeroNotDetected if the hero is ambiguous.
+func detectHero(hands []Hand, among []string) (string, error) {
+	var players []string // in order of first appearance, for stable candidate lists
+	names := make(map[string]string)
+	seated := make(map[string]int)
+	showedHeroCards := make(map[string]bool)
+	notHero := make(map[string]bool)
+	actedWithoutHeroCards := make(map[string]bool)
+	dealtHands := 0
+
+	for _, hand := range hands {
+		if len(hand.HeroCards) == 0 {
+			for _, action := range hand.Actions {
+				if action.ActionType != ActionCollect && action.ActionType != ActionUncalled {
+					actedWithoutHeroCards[action.playerKey()] = true
+				}
+			}
+			continue
+		}
+
+		dealtHands++
+		for _, player := range hand.Players {
+			key := player.key()
+			if among != nil && !slices.Contains(among, key) {
+				continue
+			}
+			if seated[key] == 0 {
+				players = append(players, key)
+				names[key] = player.DisplayName
+			}
+			seated[key]++
+		}
+		for _, winner := range hand.Winners {
+			if len(winner.HandCards) == 0 {
+				continue
+			}
+			if sameCards(winner.HandCards, hand.HeroCards) {
+				showedHeroCards[winner.playerKey()] = true
+			} else {
+				notHero[winner.playerKey()] = true
+			}
+		}
+	}
+	if dealtHands == 0 {
+		return "", nil
+	}
+
+	var showed, candidates []string
+	for _, key := range players {
+		if notHero[key] {
+			continue
+		}
+		if showedHeroCards[key] {
+			showed = append(showed, key)
+		}
+		if seated[key] == dealtHands {
+			candidates = append(candidates, key)
+		}
+	}
+
+	if len(showed) > 0 {
+		candidates = showed
+	}
+	if len(candidates) > 1 {
+		var dealtOnlyWithHeroCards []string
+		for _, key := range candidates {
+			if !actedWithoutHeroCards[key] {
+				dealtOnlyWithHeroCards = append(dealtOnlyWithHeroCards, key)
+			}
+		}
+		if len(dealtOnlyWithHeroCards) > 0 {
+			candidates = dealtOnlyWithHeroCards
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		return "", fmt.Errorf("%w: no player was seated in every hand with hero cards; set the hero name", ErrHeroNotDetected)
+	default:
+		labels := make([]string, 0, len(candidates))
+		for _, key := range candidates {
+			label := names[key]
+			if key != label {
+				label += " (" + key + ")"
+			}
+			labels = append(labels, label)
+		}
+		return "", fmt.Errorf("%w: it could be any of %s; set the hero name", ErrHeroNotDetected, strings.Join(labels, ", "))
+	}
+}
+
+// sameCards reports whether two hands hold the same cards, in any order
+func sameCards(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := slices.Sorted(slices.Values(a))
+	sortedB := slices.Sorted(slices.Values(b))
+	return slices.Equal(sortedA, sortedB)
+}
@@ -0,0 +1,164 @@
+package pokernow2gw
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDetectHero(t *testing.T) {
+	seats := func(names ...string) []Player {
+		players := make([]Player, 0, len(names))
+		for i, name := range names {
+			players = append(players, Player{SeatNumber: i + 1, DisplayName: name})
+		}
+		return players
+	}
+
+	tests := []struct {
+		name    string
+		hands   []Hand
+		want    string
+		wantErr string
+	}{
+		{
+			name: "player showing the hero cards",
+			hands: []Hand{
+				{Players: seats("alice", "bob", "carol"), HeroCards: []string{"Ah", "Kd"},
+					Winners: []Winner{{Player: "bob", Amount: 100, HandCards: []string{"Kd", "Ah"}}}},
+			},
+			want: "bob",
+		},
+		{
+			name: "only player seated in every hand with hero cards",
+			hands: []Hand{
+				{Players: seats("alice", "bob"), HeroCards: []string{"Ah", "Kd"}},
+				{Players: seats("bob", "carol"), HeroCards: []string{"2c", "2d"}},
+			},
+			want: "bob",
+		},
+		{
+			name: "player showing other cards is not the hero",
+			hands: []Hand{
+				{Players: seats("alice", "bob"), HeroCards: []string{"Ah", "Kd"},
+					Winners: []Winner{{Player: "alice", Amount: 100, HandCards: []string{"Qs", "Qc"}}}},
+			},
+			want: "bob",
+		},
+		{
+			name: "player who played a hand without hero cards is not the hero",
+			hands: []Hand{
+				{Players: seats("alice", "bob"), HeroCards: []string{"Ah", "Kd"}},
+				{Players: seats("alice", "bob"), Actions: []Action{
+					{Player: "alice", ActionType: ActionPostSB, Amount: 10},
+					{Player: "alice", ActionType: ActionFold},
+					{Player: "bob", ActionType: ActionUncalled, Amount: 10},
+				}},
+			},
+			want: "bob",
+		},
+		{
+			name: "ambiguous",
+			hands: []Hand{
+				{Players: seats("alice", "bob"), HeroCards: []string{"Ah", "Kd"}},
+			},
+			wantErr: "it could be any of alice, bob",
+		},
+		{
+			name:  "no hero cards",
+			hands: []Hand{{Players: seats("alice", "bob")}},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectHero(tt.hands, nil)
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrHeroNotDetected) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("detectHero() error = %v, want ErrHeroNotDetected containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("detectHero() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("detectHero() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveHeroKey(t *testing.T) {
+	hands := []Hand{
+		{
+			Players: []Player{{SeatNumber: 1, DisplayName: "bob", ID: "B1"}, {SeatNumber: 2, DisplayName: "carol", ID: "C1"}},
+			Actions: []Action{{Player: "bob", PlayerID: "B1", ActionType: ActionFold}},
+		},
+		{
+			Players: []Player{
+				{SeatNumber: 1, DisplayName: "whywaita", ID: "W1"},
+				{SeatNumber: 2, DisplayName: "bob", ID: "B1"},
+				{SeatNumber: 3, DisplayName: "bob", ID: "B2"},
+			},
+			HeroCards: []string{"Ah", "Kd"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		heroName string
+		want     string
+		wantErr  bool
+	}{
+		{name: "given name", heroName: "whywaita", want: "W1"},
+		{name: "spaces are removed as in display names", heroName: "why waita", want: "W1"},
+		// B1 played a hand without hero cards, so B2 is the bob who was dealt them
+		{name: "name shared by two players", heroName: "bob", want: "B2"},
+		{name: "unknown name", heroName: "dave", want: ""},
+		{name: "not given and ambiguous", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveHeroKey(tt.heroName, hands)
+			if tt.wantErr {
+				if !errors.Is(err, ErrHeroNotDetected) {
+					t.Errorf("resolveHeroKey() error = %v, want ErrHeroNotDetected", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveHeroKey() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveHeroKey(%q) = %q, want %q", tt.heroName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_DetectsHero(t *testing.T) {
+	inputPath := "../../sample/input/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.csv"
+	inputFile, err := os.Open(inputPath)
+	if os.IsNotExist(err) {
+		t.Skip("Sample input file not found, skipping hero detection test")
+	}
+	if err != nil {
+		t.Fatalf("Failed to open input file: %v", err)
+	}
+	defer inputFile.Close()
+
+	result, err := Parse(inputFile, ConvertOptions{SiteName: "PokerStars"})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if result.HeroName != "whywaita" {
+		t.Errorf("HeroName = %q, want whywaita", result.HeroName)
+	}
+	if !strings.Contains(string(result.HH), "Dealt to whywaita [") {
+		t.Error("output should deal the hero cards to the detected hero")
+	}
+}
@@ -0,0 +1,86 @@
+package pokernow2gw
+
+import "encoding/xml"
+
+// iPoker action type codes used in <action type="...">
+const (
+	iPokerActionFold  = 0
+	iPokerActionSB    = 1
+	iPokerActionBB    = 2
+	iPokerActionCall  = 3
+	iPokerActionCheck = 4
+	iPokerActionBet   = 5
+	iPokerActionAllIn = 7
+	iPokerActionAnte  = 15
+	iPokerActionRaise = 23
+)
+
+// IPokerSession represents an iPoker-style XML hand history session
+type IPokerSession struct {
+	XMLName     xml.Name         `xml:"session"`
+	SessionCode string           `xml:"sessioncode,attr"`
+	General     IPokerGeneral    `xml:"general"`
+	Games       []IPokerGameNode `xml:"game"`
+}
+
+// IPokerGeneral represents the session metadata
+type IPokerGeneral struct {
+	Mode          string `xml:"mode"`
+	GameType      string `xml:"gametype"` // e.g., "Holdem NL 1/2"
+	TableName     string `xml:"tablename"`
+	TableCurrency string `xml:"tablecurrency"`
+	SmallBlind    string `xml:"smallblind"`
+	BigBlind      string `xml:"bigblind"`
+	Ante          string `xml:"ante,omitempty"`
+	StartDate     string `xml:"startdate"`
+	GameCount     int    `xml:"gamecount"`
+	Currency      string `xml:"currency"`
+	Nickname      string `xml:"nickname"`
+	Bets          string `xml:"bets"` // Heroが拠出したチップの合計
+	Wins          string `xml:"wins"` // Heroが獲得したチップの合計
+}
+
+// IPokerGameNode represents a single hand (<game>) in the session
+type IPokerGameNode struct {
+	GameCode string            `xml:"gamecode,attr"`
+	General  IPokerGameGeneral `xml:"general"`
+	Rounds   []IPokerRound     `xml:"round"`
+}
+
+// IPokerGameGeneral represents the per-hand metadata
+type IPokerGameGeneral struct {
+	StartDate string         `xml:"startdate"`
+	Players   []IPokerPlayer `xml:"players>player"`
+}
+
+// IPokerPlayer represents a seated player of a hand
+type IPokerPlayer struct {
+	Seat   int    `xml:"seat,attr"`
+	Name   string `xml:"name,attr"`
+	Chips  string `xml:"chips,attr"` // ハンド開始時のスタック
+	Dealer int    `xml:"dealer,attr"`
+	Win    string `xml:"win,attr"`
+	Bet    string `xml:"bet,attr"`
+}
+
+// IPokerRound represents a betting round (0 = blinds and antes, 1 = preflop, 2 = flop, 3 = turn, 4 = river)
+type IPokerRound struct {
+	No      int            `xml:"no,attr"`
+	Cards   []IPokerCards  `xml:"cards"`
+	Actions []IPokerAction `xml:"action"`
+}
+
+// IPokerCards represents dealt cards, e.g. <cards type="Flop">H10 SA D2</cards>
+type IPokerCards struct {
+	Type   string `xml:"type,attr"` // Pocket, Flop, Turn, River
+	Player string `xml:"player,attr,omitempty"`
+	Cards  string `xml:",chardata"`
+}
+
+// IPokerAction represents a player action
+type IPokerAction struct {
+	No     int    `xml:"no,attr"`
+	Player string `xml:"player,attr"`
+	Type   int    `xml:"type,attr"`
+	Sum    string `xml:"sum,attr"` // このアクションで追加したチップ量
+}
@@ -0,0 +1,282 @@
+package pokernow2gw
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// iPokerTimeLayout is the timestamp layout used in iPoker XML
+const iPokerTimeLayout = "2006-01-02 15:04:05"
+
+// WriteIPokerXML writes hands as a single iPoker-style XML session document
+func WriteIPokerXML(w io.Writer, hands []Hand, opts ConvertOptions) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write iPoker XML: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(ConvertHandsToIPoker(hands, opts)); err != nil {
+		return fmt.Errorf("failed to write iPoker XML: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write iPoker XML: %w", err)
+	}
+	return nil
+}
+
+// ConvertHandsToIPoker converts hands to an iPoker-style session
+// The session metadata (table name, currency and blinds) is taken from the first hand.
+// Action sums are the chips added by each action, as in iPoker; uncalled bets are
+// left out of the player's bet total.
+func ConvertHandsToIPoker(hands []Hand, opts ConvertOptions) IPokerSession {
+	loc := opts.TimeLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	session := IPokerSession{
+		General: IPokerGeneral{
+			Mode:      "real",
+			GameCount: len(hands),
+			Nickname:  opts.HeroName,
+		},
+	}
+	if opts.GameType != GameTypeCash {
+		session.General.Mode = "tournament"
+	}
+
+	heroBets, heroWins := 0.0, 0.0
+	for _, hand := range hands {
+		game, bets, wins := convertHandToIPokerGame(hand, opts, loc)
+		session.Games = append(session.Games, game)
+		heroKey := playerKeyByName(hand, opts.HeroName)
+		heroBets += bets[heroKey]
+		heroWins += wins[heroKey]
+	}
+	session.General.Bets = formatNumber(heroBets)
+	session.General.Wins = formatNumber(heroWins)
+
+	if len(hands) == 0 {
+		return session
+	}
+
+	first := hands[0]
+	session.SessionCode = opts.TournamentID
+	if session.SessionCode == "" {
+		session.SessionCode = first.HandID
+	}
+	tableName := first.TableName
+	if tableName == "" {
+		tableName = "Poker Now"
+	}
+	currency := first.Currency
+	if currency == "" {
+		currency = "Chips"
+		if opts.GameType == GameTypeCash {
+			currency = "USD"
+		}
+	}
+	session.General.GameType = fmt.Sprintf("%s %s/%s", iPokerGameType(first), formatNumber(first.SmallBlind), formatNumber(first.BigBlind))
+	session.General.TableName = tableName
+	session.General.TableCurrency = currency
+	session.General.Currency = currency
+	session.General.SmallBlind = formatNumber(first.SmallBlind)
+	session.General.BigBlind = formatNumber(first.BigBlind)
+	if first.Ante > 0 {
+		session.General.Ante = formatNumber(first.Ante)
+	}
+	session.General.StartDate = first.StartTime.In(loc).Format(iPokerTimeLayout)
+
+	return session
+}
+
+// convertHandToIPokerGame converts a single hand to an iPoker <game>
+// It also returns the chips each player put in and won, keyed by player (see Player.key).
+func convertHandToIPokerGame(hand Hand, opts ConvertOptions, loc *time.Location) (IPokerGameNode, map[string]float64, map[string]float64) {
+	bets := playerContributions(hand)
+	wins := make(map[string]float64)
+	for _, winner := range hand.Winners {
+		wins[winner.playerKey()] = roundAmount(wins[winner.playerKey()] + winner.Amount)
+	}
+
+	game := IPokerGameNode{
+		GameCode: hand.HandID,
+		General: IPokerGameGeneral{
+			StartDate: hand.StartTime.In(loc).Format(iPokerTimeLayout),
+		},
+	}
+	dealerSeat := getDealerSeat(hand)
+	for _, player := range hand.Players {
+		dealer := 0
+		if player.SeatNumber == dealerSeat {
+			dealer = 1
+		}
+		game.General.Players = append(game.General.Players, IPokerPlayer{
+			Seat:   player.SeatNumber,
+			Name:   player.DisplayName,
+			Chips:  formatNumber(player.Stack),
+			Dealer: dealer,
+			Win:    formatNumber(wins[player.key()]),
+			Bet:    formatNumber(bets[player.key()]),
+		})
+	}
+
+	// Round 0 holds the forced bets, round 1 the pocket cards and the preflop betting
+	blinds := IPokerRound{No: 0}
+	preflop := IPokerRound{No: 1}
+	for _, player := range hand.Players {
+		cards, _ := shownHand(hand, player.key())
+		if player.DisplayName == opts.HeroName && len(hand.HeroCards) > 0 {
+			cards = hand.HeroCards
+		}
+		pocket := iPokerCards(cards)
+		if len(cards) == 0 {
+			// Unknown cards are written as "X", one per hole card
+			pocket = strings.TrimSpace(strings.Repeat("X ", holeCardCount(hand.Game)))
+		}
+		preflop.Cards = append(preflop.Cards, IPokerCards{Type: "Pocket", Player: player.DisplayName, Cards: pocket})
+	}
+
+	rounds := map[Street]*IPokerRound{StreetPreflop: &preflop}
+	var postflop []*IPokerRound
+	board := []struct {
+		street Street
+		name   string
+		cards  []string
+	}{
+		{StreetFlop, "Flop", hand.Board.Flop},
+		{StreetTurn, "Turn", []string{hand.Board.Turn}},
+		{StreetRiver, "River", []string{hand.Board.River}},
+	}
+	for i, b := range board {
+		if len(b.cards) == 0 || b.cards[0] == "" {
+			break
+		}
+		round := &IPokerRound{No: i + 2, Cards: []IPokerCards{{Type: b.name, Cards: iPokerCards(b.cards)}}}
+		rounds[b.street] = round
+		postflop = append(postflop, round)
+	}
+
+	actionNo := 0
+	committed := make(map[Street]map[string]float64)
+	for _, action := range hand.Actions {
+		actionType, ok := iPokerActionType(action)
+		if !ok {
+			continue
+		}
+		round := rounds[action.Street]
+		if actionType == iPokerActionSB || actionType == iPokerActionBB || actionType == iPokerActionAnte {
+			round = &blinds
+		}
+		if round == nil {
+			continue
+		}
+
+		// iPoker sums are the chips added by the action, while PokerNow logs street totals
+		if committed[action.Street] == nil {
+			committed[action.Street] = make(map[string]float64)
+		}
+		sum := action.Amount
+		switch action.ActionType {
+		case ActionPostSB, ActionPostBB, ActionPostStraddle, ActionPostDeadBB, ActionCall, ActionBet, ActionRaise:
+			sum = roundAmount(action.Amount - committed[action.Street][action.playerKey()])
+			committed[action.Street][action.playerKey()] = action.Amount
+		}
+
+		actionNo++
+		round.Actions = append(round.Actions, IPokerAction{
+			No:     actionNo,
+			Player: action.Player,
+			Type:   actionType,
+			Sum:    formatNumber(sum),
+		})
+	}
+
+	if len(blinds.Actions) > 0 {
+		game.Rounds = append(game.Rounds, blinds)
+	}
+	game.Rounds = append(game.Rounds, preflop)
+	for _, round := range postflop {
+		game.Rounds = append(game.Rounds, *round)
+	}
+
+	return game, bets, wins
+}
+
+// iPokerActionType returns the iPoker action type code of an action
+// Straddles and missed blinds are written as blinds; shows, collections and uncalled bets are not actions in iPoker.
+func iPokerActionType(action Action) (int, bool) {
+	switch action.ActionType {
+	case ActionFold:
+		return iPokerActionFold, true
+	case ActionCheck:
+		return iPokerActionCheck, true
+	case ActionCall, ActionBet, ActionRaise:
+		if action.IsAllIn {
+			return iPokerActionAllIn, true
+		}
+		switch action.ActionType {
+		case ActionCall:
+			return iPokerActionCall, true
+		case ActionBet:
+			return iPokerActionBet, true
+		default:
+			return iPokerActionRaise, true
+		}
+	case ActionPostSB, ActionPostDeadSB:
+		return iPokerActionSB, true
+	case ActionPostBB, ActionPostDeadBB, ActionPostStraddle:
+		return iPokerActionBB, true
+	case ActionPostAnte:
+		return iPokerActionAnte, true
+	default:
+		return 0, false
+	}
+}
+
+// iPokerGameType returns the iPoker game type label without the stakes, e.g. "Holdem NL" or "Omaha PL"
+func iPokerGameType(hand Hand) string {
+	game := "Holdem"
+	if hand.Game != PokerGameHoldem {
+		game = "Omaha"
+	}
+	limit := "NL"
+	if hand.Limit == BetLimitPotLimit {
+		limit = "PL"
+	}
+	return game + " " + limit
+}
+
+// iPokerCards converts cards ("Ah", "Td") to iPoker notation ("HA", "D10")
+func iPokerCards(cards []string) string {
+	converted := make([]string, 0, len(cards))
+	for _, card := range cards {
+		if len(card) != 2 {
+			converted = append(converted, card)
+			continue
+		}
+		rank := card[:1]
+		if rank == "T" {
+			rank = "10"
+		}
+		converted = append(converted, strings.ToUpper(card[1:])+rank)
+	}
+	return strings.Join(converted, " ")
+}
+
+// holeCardCount returns the number of hole cards dealt in the game
+func holeCardCount(game PokerGame) int {
+	switch game {
+	case PokerGameOmaha:
+		return 4
+	case PokerGameOmaha5:
+		return 5
+	case PokerGameOmaha6:
+		return 6
+	default:
+		return 2
+	}
+}
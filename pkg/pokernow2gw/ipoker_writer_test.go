@@ -0,0 +1,145 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertHandsToIPoker(t *testing.T) {
+	hand := Hand{
+		HandNumber: "1",
+		HandID:     "42",
+		Dealer:     "alice",
+		Players: []Player{
+			{SeatNumber: 1, DisplayName: "alice", Stack: 100},
+			{SeatNumber: 2, DisplayName: "bob", Stack: 50},
+		},
+		Actions: []Action{
+			{Player: "alice", ActionType: ActionPostSB, Amount: 1, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionPostBB, Amount: 2, Street: StreetPreflop},
+			{Player: "alice", ActionType: ActionRaise, Amount: 6, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionCall, Amount: 6, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionBet, Amount: 44, Street: StreetFlop, IsAllIn: true},
+			{Player: "alice", ActionType: ActionCall, Amount: 44, Street: StreetFlop},
+			{Player: "alice", ActionType: ActionShow, Street: StreetShowdown},
+			{Player: "bob", ActionType: ActionShow, Street: StreetShowdown},
+		},
+		Board:      Board{Flop: []string{"Ah", "Kd", "Tc"}, Turn: "2s", River: "3h"},
+		StartTime:  time.Date(2025, 11, 15, 5, 0, 0, 0, time.UTC),
+		SmallBlind: 1,
+		BigBlind:   2,
+		Winners: []Winner{
+			{Player: "alice", Amount: 100, HandCards: []string{"Qs", "Qh"}},
+			{Player: "bob", HandCards: []string{"7c", "2d"}},
+		},
+		HeroCards: []string{"Qs", "Qh"},
+		TableName: "Friday game",
+		Currency:  "EUR",
+	}
+
+	session := ConvertHandsToIPoker([]Hand{hand}, ConvertOptions{HeroName: "alice", GameType: GameTypeCash, TimeLocation: time.UTC})
+
+	wantGeneral := IPokerGeneral{
+		Mode:          "real",
+		GameType:      "Holdem NL 1/2",
+		TableName:     "Friday game",
+		TableCurrency: "EUR",
+		SmallBlind:    "1",
+		BigBlind:      "2",
+		StartDate:     "2025-11-15 05:00:00",
+		GameCount:     1,
+		Currency:      "EUR",
+		Nickname:      "alice",
+		Bets:          "50",
+		Wins:          "100",
+	}
+	if diff := cmp.Diff(wantGeneral, session.General); diff != "" {
+		t.Errorf("General mismatch (-want +got):\n%s", diff)
+	}
+
+	wantGame := IPokerGameNode{
+		GameCode: "42",
+		General: IPokerGameGeneral{
+			StartDate: "2025-11-15 05:00:00",
+			Players: []IPokerPlayer{
+				{Seat: 1, Name: "alice", Chips: "100", Dealer: 1, Win: "100", Bet: "50"},
+				{Seat: 2, Name: "bob", Chips: "50", Dealer: 0, Win: "0", Bet: "50"},
+			},
+		},
+		Rounds: []IPokerRound{
+			{No: 0, Actions: []IPokerAction{
+				{No: 1, Player: "alice", Type: iPokerActionSB, Sum: "1"},
+				{No: 2, Player: "bob", Type: iPokerActionBB, Sum: "2"},
+			}},
+			{No: 1, Cards: []IPokerCards{
+				{Type: "Pocket", Player: "alice", Cards: "SQ HQ"},
+				{Type: "Pocket", Player: "bob", Cards: "C7 D2"},
+			}, Actions: []IPokerAction{
+				{No: 3, Player: "alice", Type: iPokerActionRaise, Sum: "5"},
+				{No: 4, Player: "bob", Type: iPokerActionCall, Sum: "4"},
+			}},
+			{No: 2, Cards: []IPokerCards{{Type: "Flop", Cards: "HA DK C10"}}, Actions: []IPokerAction{
+				{No: 5, Player: "bob", Type: iPokerActionAllIn, Sum: "44"},
+				{No: 6, Player: "alice", Type: iPokerActionCall, Sum: "44"},
+			}},
+			{No: 3, Cards: []IPokerCards{{Type: "Turn", Cards: "S2"}}},
+			{No: 4, Cards: []IPokerCards{{Type: "River", Cards: "H3"}}},
+		},
+	}
+	if diff := cmp.Diff([]IPokerGameNode{wantGame}, session.Games); diff != "" {
+		t.Errorf("Games mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIPokerOutputFormat(t *testing.T) {
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,10
+"""player1 @ id1"" collected 40 from pot",2025-11-15T05:09:14.567Z,9
+"""player2 @ id2"" folds",2025-11-15T05:09:14.567Z,8
+"""player1 @ id1"" bets 50",2025-11-15T05:09:14.567Z,7
+"""player2 @ id2"" calls 20",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,3
+"Player stacks: #1 ""player1 @ id1"" (1500) | #2 ""player2 @ id2"" (1500)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,1`
+
+	result, err := Parse(strings.NewReader(csv), ConvertOptions{HeroName: "player1", OutputFormat: OutputFormatIPokerXML})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if !bytes.HasPrefix(result.HH, []byte(xml.Header)) {
+		t.Errorf("output does not start with the XML header:\n%s", result.HH)
+	}
+
+	var session IPokerSession
+	if err := xml.Unmarshal(result.HH, &session); err != nil {
+		t.Fatalf("output is not valid iPoker XML: %v\n%s", err, result.HH)
+	}
+	if len(session.Games) != 1 || session.General.Nickname != "player1" || session.General.TableName != "Poker Now" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+}
+
+func TestIPokerCards(t *testing.T) {
+	tests := []struct {
+		cards []string
+		want  string
+	}{
+		{cards: []string{"Ah", "Kd"}, want: "HA DK"},
+		{cards: []string{"Ts", "9c"}, want: "S10 C9"},
+		{cards: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		got := iPokerCards(tt.cards)
+		if got != tt.want {
+			t.Errorf("iPokerCards(%v) = %q, want %q", tt.cards, got, tt.want)
+		}
+	}
+}
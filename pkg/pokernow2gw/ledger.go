@@ -0,0 +1,234 @@
+package pokernow2gw
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+)
+
+var (
+	// Session events logged between (and sometimes inside) hands
+	reLedgerApproved = regexp.MustCompile(`^The admin approved the player "(.+?)" participation with a stack of (\d+(?:\.\d+)?)\.$`)
+	reLedgerJoined   = regexp.MustCompile(`^The player "(.+?)" joined the game with a stack of (\d+(?:\.\d+)?)\.$`)
+	reLedgerQuits    = regexp.MustCompile(`^The player "(.+?)" (?:quits|left) the game with a stack of (\d+(?:\.\d+)?)\.$`)
+	reLedgerStandUp  = regexp.MustCompile(`^The player "(.+?)" stand up with the stack of (\d+(?:\.\d+)?)\.$`)
+	reLedgerSitBack  = regexp.MustCompile(`^The player "(.+?)" sit back with the stack of (\d+(?:\.\d+)?)\.$`)
+	reLedgerStackSet = regexp.MustCompile(`^The admin(?: "[^"]+")? updated the player "(.+?)" stack from (\d+(?:\.\d+)?) to (\d+(?:\.\d+)?)\.$`)
+	// A seat request moves no chips until the admin approves it
+	reSeatRequested = regexp.MustCompile(`^The player "(.+?)" requested a seat\.$`)
+)
+
+// isSessionEntry reports whether an entry is a session event rather than part of a hand
+func isSessionEntry(text string) bool {
+	for _, re := range []*regexp.Regexp{reLedgerApproved, reLedgerJoined, reLedgerQuits, reLedgerStandUp, reLedgerSitBack, reLedgerStackSet, reSeatRequested} {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// LedgerEventType represents the kind of a session ledger event
+type LedgerEventType string
+
+const (
+	LedgerBuyIn       LedgerEventType = "buy_in"       // 最初の着席（承認）
+	LedgerRebuy       LedgerEventType = "rebuy"        // 退席後の再着席
+	LedgerTopUp       LedgerEventType = "top_up"       // 管理者によるスタック追加
+	LedgerRemoveChips LedgerEventType = "remove_chips" // 管理者によるスタック削減
+	LedgerCashOut     LedgerEventType = "cash_out"     // 退席（quits）
+	LedgerStandUp     LedgerEventType = "stand_up"     // 離席（チップ移動なし）
+	LedgerSitBack     LedgerEventType = "sit_back"     // 復帰（チップ移動なし）
+)
+
+// LedgerEvent is a single entry of a player's session timeline
+type LedgerEvent struct {
+	At         time.Time       `json:"at"`
+	PlayerID   string          `json:"player_id"`
+	PlayerName string          `json:"player_name"`
+	Type       LedgerEventType `json:"type"`
+	Amount     float64         `json:"amount"` // 移動したチップ量（stand_up / sit_back は 0）
+	Stack      float64         `json:"stack"`  // イベント後のスタック
+}
+
+// LedgerPlayer is the settlement summary of one player
+type LedgerPlayer struct {
+	PlayerID   string  `json:"player_id"`
+	PlayerName string  `json:"player_name"` // 最後に使われた表示名
+	BuyIn      float64 `json:"buy_in"`      // buy-in + rebuy + top-up - remove_chips
+	CashOut    float64 `json:"cash_out"`
+	Seated     bool    `json:"seated"` // ログ終了時に着席中か
+	Stack      float64 `json:"stack"`  // 着席中の場合、最後に分かっているスタック
+	Net        float64 `json:"net"`    // CashOut + Stack (着席中のみ) - BuyIn
+}
+
+// Ledger is the buy-in / cash-out history of a PokerNow session
+// Players are keyed by their PokerNow player ID, so renames do not split a player in two.
+type Ledger struct {
+	Events  []LedgerEvent  `json:"events"`
+	Players []LedgerPlayer `json:"players"`
+}
+
+// ledgerBuilder accumulates ledger events from chronologically ordered log entries
+type ledgerBuilder struct {
+	events  []LedgerEvent
+	players map[string]*LedgerPlayer
+	order   []string // 初登場順のプレイヤーID
+}
+
+func newLedgerBuilder() *ledgerBuilder {
+	return &ledgerBuilder{players: make(map[string]*LedgerPlayer)}
+}
+
+// BuildLedger builds the session ledger from log entries in chronological order
+func BuildLedger(entries []LogEntry) *Ledger {
+	b := newLedgerBuilder()
+	for _, entry := range entries {
+		b.observe(entry)
+	}
+	return b.ledger()
+}
+
+// player returns the ledger summary for a PokerNow name ("name @ id"), creating it on first sight
+func (b *ledgerBuilder) player(fullName string) *LedgerPlayer {
+	id := extractPlayerID(fullName)
+	p, ok := b.players[id]
+	if !ok {
+		p = &LedgerPlayer{PlayerID: id}
+		b.players[id] = p
+		b.order = append(b.order, id)
+	}
+	p.PlayerName = extractDisplayName(fullName)
+	return p
+}
+
+func (b *ledgerBuilder) record(p *LedgerPlayer, at time.Time, eventType LedgerEventType, amount float64) {
+	b.events = append(b.events, LedgerEvent{
+		At:         at,
+		PlayerID:   p.PlayerID,
+		PlayerName: p.PlayerName,
+		Type:       eventType,
+		Amount:     amount,
+		Stack:      p.Stack,
+	})
+}
+
+// sitDown handles an approval or join. Only the first one while the player is away moves chips:
+// PokerNow logs both "approved ... participation" and "joined the game" for the same seat.
+func (b *ledgerBuilder) sitDown(fullName string, stack float64, at time.Time) {
+	p := b.player(fullName)
+	if p.Seated {
+		return
+	}
+	eventType := LedgerBuyIn
+	if p.BuyIn > 0 || p.CashOut > 0 {
+		eventType = LedgerRebuy
+	}
+	p.Seated = true
+	p.Stack = stack
+	p.BuyIn = roundAmount(p.BuyIn + stack)
+	b.record(p, at, eventType, stack)
+}
+
+// observe updates the ledger with a single log entry; unrelated entries are ignored
+func (b *ledgerBuilder) observe(entry LogEntry) {
+	text := entry.Entry
+	switch {
+	case reLedgerApproved.MatchString(text):
+		m := reLedgerApproved.FindStringSubmatch(text)
+		stack, _ := parseAmount(m[2])
+		b.sitDown(m[1], stack, entry.At)
+	case reLedgerJoined.MatchString(text):
+		m := reLedgerJoined.FindStringSubmatch(text)
+		stack, _ := parseAmount(m[2])
+		b.sitDown(m[1], stack, entry.At)
+	case reLedgerQuits.MatchString(text):
+		m := reLedgerQuits.FindStringSubmatch(text)
+		stack, _ := parseAmount(m[2])
+		p := b.player(m[1])
+		p.Seated = false
+		p.Stack = 0
+		p.CashOut = roundAmount(p.CashOut + stack)
+		b.record(p, entry.At, LedgerCashOut, stack)
+	case reLedgerStandUp.MatchString(text):
+		m := reLedgerStandUp.FindStringSubmatch(text)
+		p := b.player(m[1])
+		p.Stack, _ = parseAmount(m[2])
+		b.record(p, entry.At, LedgerStandUp, 0)
+	case reLedgerSitBack.MatchString(text):
+		m := reLedgerSitBack.FindStringSubmatch(text)
+		p := b.player(m[1])
+		p.Stack, _ = parseAmount(m[2])
+		b.record(p, entry.At, LedgerSitBack, 0)
+	case reLedgerStackSet.MatchString(text):
+		m := reLedgerStackSet.FindStringSubmatch(text)
+		from, _ := parseAmount(m[2])
+		to, _ := parseAmount(m[3])
+		p := b.player(m[1])
+		p.Stack = to
+		diff := roundAmount(to - from)
+		p.BuyIn = roundAmount(p.BuyIn + diff)
+		if diff >= 0 {
+			b.record(p, entry.At, LedgerTopUp, diff)
+		} else {
+			b.record(p, entry.At, LedgerRemoveChips, -diff)
+		}
+	case rePlayerStacks.MatchString(text):
+		// Keep the last known stack of seated players up to date (the parser reports stacks that are not numbers)
+		seats, _ := parsePlayerStacks(rePlayerStacks.FindStringSubmatch(text)[1])
+		for _, seated := range seats {
+			if p, ok := b.players[extractPlayerID(seated.Name)]; ok && p.Seated {
+				p.Stack = seated.Stack
+			}
+		}
+	}
+}
+
+func (b *ledgerBuilder) ledger() *Ledger {
+	ledger := &Ledger{Events: b.events}
+	for _, id := range b.order {
+		p := *b.players[id]
+		if !p.Seated {
+			p.Stack = 0
+		}
+		p.Net = roundAmount(p.CashOut + p.Stack - p.BuyIn)
+		ledger.Players = append(ledger.Players, p)
+	}
+	return ledger
+}
+
+// WriteCSV writes the ledger timeline as CSV, one row per event
+func (l *Ledger) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"at", "player_id", "player_name", "type", "amount", "stack"}); err != nil {
+		return fmt.Errorf("failed to write ledger CSV header: %w", err)
+	}
+	for _, e := range l.Events {
+		record := []string{
+			e.At.UTC().Format(time.RFC3339Nano),
+			e.PlayerID,
+			e.PlayerName,
+			string(e.Type),
+			formatNumber(e.Amount),
+			formatNumber(e.Stack),
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write ledger CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the ledger (timeline and per-player summary) as indented JSON
+func (l *Ledger) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(l); err != nil {
+		return fmt.Errorf("failed to write ledger JSON: %w", err)
+	}
+	return nil
+}
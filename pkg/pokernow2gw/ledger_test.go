@@ -0,0 +1,126 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildLedger(t *testing.T) {
+	baseTime := time.Date(2025, 11, 15, 3, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return baseTime.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	entries := []LogEntry{
+		{Entry: `The player "alice @ A1" requested a seat.`, At: at(0)},
+		{Entry: `The admin approved the player "alice @ A1" participation with a stack of 100.`, At: at(1)},
+		{Entry: `The player "alice @ A1" joined the game with a stack of 100.`, At: at(2)},
+		{Entry: `The admin approved the player "bob @ B2" participation with a stack of 100.`, At: at(3)},
+		{Entry: `-- starting hand #1 (No Limit Texas Hold'em) (dealer: "alice @ A1") --`, At: at(4)},
+		{Entry: `Player stacks: #1 "alice @ A1" (100) | #2 "bob @ B2" (100)`, At: at(4)},
+		{Entry: `-- ending hand #1 --`, At: at(5)},
+		{Entry: `The player "bob @ B2" quits the game with a stack of 0.`, At: at(6)},
+		// bob rebuys under a new nickname; the ID keeps him one player
+		{Entry: `The admin approved the player "bobby @ B2" participation with a stack of 100.`, At: at(7)},
+		{Entry: `The player "alice @ A1" stand up with the stack of 190.`, At: at(8)},
+		{Entry: `The player "alice @ A1" sit back with the stack of 190.`, At: at(9)},
+		{Entry: `The admin "alice @ A1" updated the player "alice @ A1" stack from 190 to 250.`, At: at(10)},
+		{Entry: `The admin updated the player "bobby @ B2" stack from 100 to 90.5.`, At: at(11)},
+		{Entry: `The player "alice @ A1" quits the game with a stack of 300.`, At: at(12)},
+	}
+
+	got := BuildLedger(entries)
+
+	wantEvents := []LedgerEvent{
+		{At: at(1), PlayerID: "A1", PlayerName: "alice", Type: LedgerBuyIn, Amount: 100, Stack: 100},
+		{At: at(3), PlayerID: "B2", PlayerName: "bob", Type: LedgerBuyIn, Amount: 100, Stack: 100},
+		{At: at(6), PlayerID: "B2", PlayerName: "bob", Type: LedgerCashOut, Amount: 0, Stack: 0},
+		{At: at(7), PlayerID: "B2", PlayerName: "bobby", Type: LedgerRebuy, Amount: 100, Stack: 100},
+		{At: at(8), PlayerID: "A1", PlayerName: "alice", Type: LedgerStandUp, Amount: 0, Stack: 190},
+		{At: at(9), PlayerID: "A1", PlayerName: "alice", Type: LedgerSitBack, Amount: 0, Stack: 190},
+		{At: at(10), PlayerID: "A1", PlayerName: "alice", Type: LedgerTopUp, Amount: 60, Stack: 250},
+		{At: at(11), PlayerID: "B2", PlayerName: "bobby", Type: LedgerRemoveChips, Amount: 9.5, Stack: 90.5},
+		{At: at(12), PlayerID: "A1", PlayerName: "alice", Type: LedgerCashOut, Amount: 300, Stack: 0},
+	}
+	if diff := cmp.Diff(wantEvents, got.Events); diff != "" {
+		t.Errorf("Events mismatch (-want +got):\n%s", diff)
+	}
+
+	wantPlayers := []LedgerPlayer{
+		{PlayerID: "A1", PlayerName: "alice", BuyIn: 160, CashOut: 300, Net: 140},
+		{PlayerID: "B2", PlayerName: "bobby", BuyIn: 190.5, Seated: true, Stack: 90.5, Net: -100},
+	}
+	if diff := cmp.Diff(wantPlayers, got.Players); diff != "" {
+		t.Errorf("Players mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLedgerWriters(t *testing.T) {
+	ledger := &Ledger{
+		Events: []LedgerEvent{
+			{At: time.Date(2025, 11, 15, 3, 0, 0, 0, time.UTC), PlayerID: "A1", PlayerName: "alice", Type: LedgerBuyIn, Amount: 0.5, Stack: 0.5},
+		},
+		Players: []LedgerPlayer{
+			{PlayerID: "A1", PlayerName: "alice", BuyIn: 0.5, Seated: true, Stack: 0.5},
+		},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ledger.WriteCSV(&buf); err != nil {
+			t.Fatalf("WriteCSV() failed: %v", err)
+		}
+		want := "at,player_id,player_name,type,amount,stack\n2025-11-15T03:00:00Z,A1,alice,buy_in,0.50,0.50\n"
+		if buf.String() != want {
+			t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := ledger.WriteJSON(&buf); err != nil {
+			t.Fatalf("WriteJSON() failed: %v", err)
+		}
+		var decoded Ledger
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("WriteJSON() produced invalid JSON: %v", err)
+		}
+		if diff := cmp.Diff(ledger, &decoded); diff != "" {
+			t.Errorf("JSON round trip mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestConvertResultLedger_Sample(t *testing.T) {
+	csv := `entry,at,order
+"The player ""piyo @ 8_zd4NO6-H"" joined the game with a stack of 50000.",2025-11-15T03:49:46.920Z,5
+"The admin approved the player ""piyo @ 8_zd4NO6-H"" participation with a stack of 50000.",2025-11-15T03:49:30.912Z,4
+"The player ""piyo @ 8_zd4NO6-H"" quits the game with a stack of 0.",2025-11-15T03:49:12.992Z,3
+"The player ""piyo @ 8_zd4NO6-H"" joined the game with a stack of 50000.",2025-11-15T03:00:08.344Z,2
+"The admin approved the player ""piyo @ 8_zd4NO6-H"" participation with a stack of 50000.",2025-11-15T02:53:57.055Z,1`
+
+	result, err := ParseCSV(strings.NewReader(csv), ConvertOptions{HeroName: "piyo"})
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+	if result.Ledger == nil {
+		t.Fatal("ConvertResult.Ledger is nil for CSV input")
+	}
+
+	var types []LedgerEventType
+	for _, e := range result.Ledger.Events {
+		types = append(types, e.Type)
+	}
+	wantTypes := []LedgerEventType{LedgerBuyIn, LedgerCashOut, LedgerRebuy}
+	if diff := cmp.Diff(wantTypes, types); diff != "" {
+		t.Errorf("event types mismatch (-want +got):\n%s", diff)
+	}
+	if net := result.Ledger.Players[0].Net; net != -50000 {
+		t.Errorf("Net = %v, want -50000", net)
+	}
+}
@@ -1,21 +1,27 @@
 package pokernow2gw
 
 import (
+	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 )
 
-const (
-	// maxJSONLLines is the maximum number of lines to process in a JSONL file
-	// to prevent excessive memory usage
-	maxJSONLLines = 10000
-)
-
 // ReadOHH reads Open Hand History JSON from reader and converts to internal Hand format
 // Supports both simplified OHH format and official OHH spec format
 func ReadOHH(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
+	result, err := readOHH(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertReadResult(result, opts)
+}
+
+// readOHH reads a single Open Hand History JSON document into hands
+func readOHH(r io.Reader, opts ConvertOptions) (*ReadResult, error) {
 	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read OHH JSON: %w", err)
@@ -24,7 +30,7 @@ func ReadOHH(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
 	// Try to detect which OHH format this is
 	var formatCheck map[string]interface{}
 	if err := json.Unmarshal(data, &formatCheck); err != nil {
-		return nil, fmt.Errorf("failed to decode OHH JSON: %w", err)
+		return nil, &ParseError{Kind: ParseErrorJSON, Err: fmt.Errorf("failed to decode OHH JSON: %w", err)}
 	}
 
 	// Check if this is the official OHH spec format (has "ohh" field)
@@ -37,24 +43,18 @@ func ReadOHH(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
 }
 
 // readSimplifiedOHHFormat reads the simplified OHH format
-func readSimplifiedOHHFormat(data []byte, opts ConvertOptions) (*ConvertResult, error) {
+func readSimplifiedOHHFormat(data []byte, opts ConvertOptions) (*ReadResult, error) {
 	var ohhFormat OHHFormat
 	if err := json.Unmarshal(data, &ohhFormat); err != nil {
-		return nil, fmt.Errorf("failed to decode OHH JSON: %w", err)
-	}
-
-	// Set defaults
-	if opts.SiteName == "" {
-		opts.SiteName = "PokerStars"
-	}
-	if opts.TimeLocation == nil && len(ohhFormat.Hands) > 0 {
-		opts.TimeLocation = ohhFormat.Hands[0].StartTime.Location()
+		return nil, &ParseError{Kind: ParseErrorJSON, Err: fmt.Errorf("failed to decode OHH JSON: %w", err)}
 	}
 
 	// Convert OHH hands to internal Hand format
 	hands := make([]Hand, 0, len(ohhFormat.Hands))
+	var warnings []Warning
+	var checks map[string]handCheck
 	for _, ohhHand := range ohhFormat.Hands {
-		hand, err := convertOHHHandToHand(ohhHand)
+		hand, handWarnings, err := convertOHHHandToHand(ohhHand)
 		if err != nil {
 			// Skip invalid hands (e.g., too many players, unsupported actions)
 			continue
@@ -68,6 +68,11 @@ func readSimplifiedOHHFormat(data []byte, opts ConvertOptions) (*ConvertResult,
 		}
 
 		hands = append(hands, hand)
+		warnings = append(warnings, handWarnings...)
+		checks = checkHand(checks, hand, func() []string {
+			raw, _ := json.Marshal(ohhHand)
+			return []string{string(raw)}
+		})
 	}
 
 	// Check if this is a spectator log (no hero cards in any hand)
@@ -75,35 +80,22 @@ func readSimplifiedOHHFormat(data []byte, opts ConvertOptions) (*ConvertResult,
 		return nil, ErrSpectatorLog
 	}
 
-	// Convert to HH format
-	hh := convertHandsToHH(hands, opts)
-
-	return &ConvertResult{
-		HH:           []byte(hh),
-		SkippedHands: 0,
-	}, nil
+	result := &ReadResult{Hands: hands, Warnings: warnings, checks: checks}
+	if len(ohhFormat.Hands) > 0 {
+		result.TimeLocation = ohhFormat.Hands[0].StartTime.Location()
+	}
+	return result, nil
 }
 
 // readOHHSpecFormat reads the official OHH specification format
-func readOHHSpecFormat(data []byte, opts ConvertOptions) (*ConvertResult, error) {
+func readOHHSpecFormat(data []byte, opts ConvertOptions) (*ReadResult, error) {
 	var specFormat OHHSpecFormat
 	if err := json.Unmarshal(data, &specFormat); err != nil {
-		return nil, fmt.Errorf("failed to decode OHH spec JSON: %w", err)
-	}
-
-	// Set defaults
-	if opts.SiteName == "" {
-		opts.SiteName = specFormat.OHH.SiteName
-		if opts.SiteName == "" {
-			opts.SiteName = "PokerStars"
-		}
-	}
-	if opts.TimeLocation == nil {
-		opts.TimeLocation = specFormat.OHH.StartDateUTC.Location()
+		return nil, &ParseError{Kind: ParseErrorJSON, Err: fmt.Errorf("failed to decode OHH spec JSON: %w", err)}
 	}
 
 	// Convert OHH spec to internal Hand format
-	hand, err := convertOHHSpecToHand(specFormat.OHH, opts)
+	hand, warnings, err := convertOHHSpecToHand(specFormat.OHH, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert OHH spec: %w", err)
 	}
@@ -115,20 +107,34 @@ func readOHHSpecFormat(data []byte, opts ConvertOptions) (*ConvertResult, error)
 		return nil, ErrSpectatorLog
 	}
 
-	// Convert to HH format
-	hh := convertHandsToHH(hands, opts)
-
-	return &ConvertResult{
-		HH:           []byte(hh),
-		SkippedHands: 0,
+	return &ReadResult{
+		Hands:        hands,
+		SiteName:     specFormat.OHH.SiteName,
+		TimeLocation: specFormat.OHH.StartDateUTC.Location(),
+		HeroName:     ohhHeroName(specFormat.OHH),
+		Warnings:     warnings,
+		checks:       checkHand(nil, hand, func() []string { return strings.Split(strings.TrimSpace(string(data)), "\n") }),
 	}, nil
 }
 
+// ohhHeroName returns the name of the player marked as the hero, if any
+func ohhHeroName(spec OHHSpec) string {
+	for _, p := range spec.Players {
+		if spec.HeroPlayerID > 0 && p.ID == spec.HeroPlayerID {
+			return p.Name
+		}
+	}
+	return ""
+}
+
 // convertOHHSpecToHand converts an OHH spec to internal Hand format
-func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
-	// Check bet_type - only NL is supported
-	if spec.BetLimit.BetType != "NL" {
-		return Hand{}, fmt.Errorf("unsupported bet type: %s (only NL is supported)", spec.BetLimit.BetType)
+// It also returns warnings for the seat and hand ID changes made for GTO Wizard.
+// Hands that cannot be converted are reported as a ParseError.
+func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, []Warning, error) {
+	// Check game_type and bet_type - only NL Hold'em and PL Omaha are supported
+	game, limit, err := convertOHHGame(spec)
+	if err != nil {
+		return Hand{}, nil, &ParseError{Kind: ParseErrorUnsupportedGame, HandNumber: spec.GameNumber, Err: err}
 	}
 
 	// Create player map for quick lookup
@@ -144,29 +150,31 @@ func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
 			SeatNumber:  p.Seat,
 			Name:        p.Name,
 			DisplayName: p.Name,
+			ID:          p.UID,
 			Stack:       p.StartingStack,
 		})
 	}
 
 	// Normalize player seats (renumber from 1 to N)
+	loggedPlayers := players
 	players = normalizePlayerSeats(players)
 
 	// Check player count (GTO Wizard limit: 2-10 players)
 	playerCount := len(players)
 	if playerCount > 10 {
-		return Hand{}, fmt.Errorf("hand has %d players, but GTO Wizard only supports up to 10 players", playerCount)
+		return Hand{}, nil, &ParseError{Kind: ParseErrorInvalidHand, HandNumber: spec.GameNumber, Err: fmt.Errorf("hand has %d players, but GTO Wizard only supports up to 10 players", playerCount)}
 	}
 
 	// Apply player count filter based on GTO Wizard plan
 	if !opts.PlayerCountFilter.isPlayerCountAllowed(playerCount) {
-		return Hand{}, fmt.Errorf("hand has %d players, which does not match the selected filter", playerCount)
+		return Hand{}, nil, &ParseError{Kind: ParseErrorInvalidHand, HandNumber: spec.GameNumber, Err: fmt.Errorf("hand has %d players, which does not match the selected filter", playerCount)}
 	}
 
 	// Find dealer name
-	dealerName := ""
+	dealerName, dealerID := "", ""
 	for _, p := range players {
 		if p.SeatNumber == spec.DealerSeat {
-			dealerName = p.DisplayName
+			dealerName, dealerID = p.DisplayName, p.ID
 			break
 		}
 	}
@@ -200,9 +208,15 @@ func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
 	}
 
 	// Convert actions from rounds
+	// OHH calls are the chips added by the call, so they are converted back to street totals. The
+	// uncalled bet, which OHH leaves out of the actions, is restored from the commitments of the last
+	// street with bets.
 	var actions []Action
+	var lastCommitted map[int]float64
+	var lastStreet Street
 	for _, round := range spec.Rounds {
 		street := convertOHHStreet(round.Street)
+		committed := make(map[int]float64)
 		for _, a := range round.Actions {
 			player, ok := playerMap[a.PlayerID]
 			if !ok {
@@ -211,18 +225,35 @@ func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
 
 			actionType, err := convertOHHActionType(a.Action)
 			if err != nil {
-				return Hand{}, fmt.Errorf("in round %q action #%d: %w", round.Street, a.ActionNumber, err)
+				return Hand{}, nil, &ParseError{Kind: ParseErrorInvalidHand, HandNumber: spec.GameNumber, Err: fmt.Errorf("in round %q action #%d: %w", round.Street, a.ActionNumber, err)}
+			}
+
+			amount := a.Amount
+			switch actionType {
+			case ActionCall:
+				amount = roundAmount(committed[a.PlayerID] + a.Amount)
+				committed[a.PlayerID] = amount
+			case ActionPostSB, ActionPostBB, ActionPostStraddle, ActionPostDeadBB, ActionBet, ActionRaise:
+				committed[a.PlayerID] = amount
 			}
 
 			action := Action{
 				Player:     player.Name,
+				PlayerID:   player.UID,
 				ActionType: actionType,
-				Amount:     a.Amount,
+				Amount:     amount,
 				Street:     street,
 				IsAllIn:    a.IsAllIn,
 			}
 			actions = append(actions, action)
 		}
+		if len(committed) > 0 {
+			lastCommitted, lastStreet = committed, street
+		}
+	}
+	hasUncalled := slices.ContainsFunc(actions, func(a Action) bool { return a.ActionType == ActionUncalled })
+	if uncalled, ok := uncalledBet(lastCommitted, lastStreet, playerMap); ok && !hasUncalled {
+		actions = append(actions, uncalled)
 	}
 
 	// Convert pots to winners
@@ -242,6 +273,7 @@ func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
 
 			winners = append(winners, Winner{
 				Player:    player.Name,
+				PlayerID:  player.UID,
 				Amount:    win.WinAmount,
 				HandCards: handCards,
 			})
@@ -254,12 +286,14 @@ func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
 		handID = "1"
 	}
 	// Normalize to numeric ID for GTO Wizard compatibility
-	handID = convertHandIDToNumeric(handID)
+	numericID := convertHandIDToNumeric(handID)
+	warnings := normalizationWarnings(handID, numericID, loggedPlayers)
 
 	return Hand{
-		HandNumber: handID,
-		HandID:     handID,
+		HandNumber: numericID,
+		HandID:     numericID,
 		Dealer:     dealerName,
+		DealerID:   dealerID,
 		Players:    players,
 		Actions:    actions,
 		Board:      board,
@@ -272,11 +306,37 @@ func convertOHHSpecToHand(spec OHHSpec, opts ConvertOptions) (Hand, error) {
 		TableName:  spec.TableName,
 		SiteName:   spec.SiteName,
 		Currency:   spec.Currency,
-	}, nil
+		Game:       game,
+		Limit:      limit,
+	}, warnings, nil
+}
+
+// convertOHHGame converts the OHH spec game_type and bet_type to PokerGame and BetLimit.
+// Supported combinations are NL Holdem and PL Omaha (4, 5 or 6 cards).
+// The Omaha variant is derived from the number of hole cards dealt to the players.
+func convertOHHGame(spec OHHSpec) (PokerGame, BetLimit, error) {
+	gameType := strings.ToLower(spec.GameType)
+	betType := spec.BetLimit.BetType
+
+	switch {
+	case (gameType == "holdem" || gameType == "") && betType == "NL":
+		return PokerGameHoldem, BetLimitNoLimit, nil
+	case gameType == "omaha" && betType == "PL":
+		cardCount := 0
+		for _, p := range spec.Players {
+			if len(p.Cards) > cardCount {
+				cardCount = len(p.Cards)
+			}
+		}
+		return omahaVariantForCards(PokerGameOmaha, cardCount), BetLimitPotLimit, nil
+	default:
+		return PokerGameHoldem, BetLimitNoLimit, fmt.Errorf("unsupported game: %s %s (only NL Holdem and PL Omaha are supported)", spec.BetLimit.BetType, spec.GameType)
+	}
 }
 
 // convertOHHHandToHand converts an OHH hand to internal Hand format
-func convertOHHHandToHand(ohhHand OHHHand) (Hand, error) {
+// It also returns warnings for the seat and hand ID changes made for GTO Wizard.
+func convertOHHHandToHand(ohhHand OHHHand) (Hand, []Warning, error) {
 	// Convert players
 	players := make([]Player, 0, len(ohhHand.Players))
 	for _, p := range ohhHand.Players {
@@ -289,12 +349,13 @@ func convertOHHHandToHand(ohhHand OHHHand) (Hand, error) {
 	}
 
 	// Normalize player seats (renumber from 1 to N)
+	loggedPlayers := players
 	players = normalizePlayerSeats(players)
 
 	// Check player count (GTO Wizard limit: 2-10 players)
 	playerCount := len(players)
 	if playerCount > 10 {
-		return Hand{}, fmt.Errorf("hand %s has %d players, but GTO Wizard only supports up to 10 players", ohhHand.HandID, playerCount)
+		return Hand{}, nil, fmt.Errorf("hand %s has %d players, but GTO Wizard only supports up to 10 players", ohhHand.HandID, playerCount)
 	}
 
 	// Find dealer name
@@ -314,17 +375,31 @@ func convertOHHHandToHand(ohhHand OHHHand) (Hand, error) {
 	}
 
 	// Convert actions
+	// Calls are the chips added by the call, as in the OHH spec, and are converted to street totals
 	actions := make([]Action, 0, len(ohhHand.Actions))
+	var street Street
+	committed := make(map[string]float64)
 	for _, a := range ohhHand.Actions {
 		actionType, err := convertOHHActionType(a.ActionType)
 		if err != nil {
-			return Hand{}, fmt.Errorf("in hand %s action for player %q: %w", ohhHand.HandID, a.Player, err)
+			return Hand{}, nil, fmt.Errorf("in hand %s action for player %q: %w", ohhHand.HandID, a.Player, err)
+		}
+		if s := convertOHHStreet(a.Street); s != street {
+			street, committed = s, make(map[string]float64)
+		}
+		amount := a.Amount
+		switch actionType {
+		case ActionCall:
+			amount = roundAmount(committed[a.Player] + a.Amount)
+			committed[a.Player] = amount
+		case ActionPostSB, ActionPostBB, ActionPostStraddle, ActionPostDeadBB, ActionBet, ActionRaise:
+			committed[a.Player] = amount
 		}
 		action := Action{
 			Player:     a.Player,
 			ActionType: actionType,
-			Amount:     a.Amount,
-			Street:     convertOHHStreet(a.Street),
+			Amount:     amount,
+			Street:     street,
 			IsAllIn:    a.IsAllIn,
 		}
 		actions = append(actions, action)
@@ -340,9 +415,23 @@ func convertOHHHandToHand(ohhHand OHHHand) (Hand, error) {
 		})
 	}
 
+	// Game type is optional in the simplified format; default to NL Hold'em
+	game, limit := PokerGameHoldem, BetLimitNoLimit
+	if ohhHand.GameType != "" {
+		var ok bool
+		game, limit, ok = parsePokerNowGame(ohhHand.GameType)
+		if !ok {
+			return Hand{}, nil, fmt.Errorf("hand %s has unsupported game type %q", ohhHand.HandID, ohhHand.GameType)
+		}
+		if len(ohhHand.HeroCards) > 0 {
+			game = omahaVariantForCards(game, len(ohhHand.HeroCards))
+		}
+	}
+
 	// Normalize hand ID to numeric for GTO Wizard compatibility
 	handNumber := convertHandIDToNumeric(ohhHand.HandNumber)
 	handID := convertHandIDToNumeric(ohhHand.HandID)
+	warnings := normalizationWarnings(ohhHand.HandID, handID, loggedPlayers)
 
 	return Hand{
 		HandNumber: handNumber,
@@ -357,7 +446,9 @@ func convertOHHHandToHand(ohhHand OHHHand) (Hand, error) {
 		Ante:       ohhHand.Ante,
 		Winners:    winners,
 		HeroCards:  ohhHand.HeroCards,
-	}, nil
+		Game:       game,
+		Limit:      limit,
+	}, warnings, nil
 }
 
 // convertOHHActionType converts OHH action type string to ActionType.
@@ -384,7 +475,13 @@ func convertOHHActionType(actionType string) (ActionType, error) {
 		return ActionPostBB, nil
 	case "post ante", "postante":
 		return ActionPostAnte, nil
-	case "show":
+	case "straddle", "post straddle", "poststraddle":
+		return ActionPostStraddle, nil
+	case "post dead", "postdead", "post dead sb", "postdeadsb":
+		return ActionPostDeadSB, nil
+	case "post dead bb", "postdeadbb", "post extra blind":
+		return ActionPostDeadBB, nil
+	case "show", "shows cards":
 		return ActionShow, nil
 	case "collect":
 		return ActionCollect, nil
@@ -395,6 +492,29 @@ func convertOHHActionType(actionType string) (ActionType, error) {
 	}
 }
 
+// uncalledBet returns the uncalled bet action for the largest commitment of a street, if nobody matched it in full
+func uncalledBet(committed map[int]float64, street Street, playerMap map[int]*OHHSpecPlayer) (Action, bool) {
+	top, topID, called := 0.0, 0, 0.0
+	for id, amount := range committed {
+		if amount > top {
+			called, top, topID = top, amount, id
+		} else if amount > called {
+			called = amount
+		}
+	}
+	player, ok := playerMap[topID]
+	if !ok || top-called <= amountTolerance {
+		return Action{}, false
+	}
+	return Action{
+		Player:     player.Name,
+		PlayerID:   player.UID,
+		ActionType: ActionUncalled,
+		Amount:     roundAmount(top - called),
+		Street:     street,
+	}, true
+}
+
 // convertOHHStreet converts OHH street string to Street.
 // Handles both simplified format (e.g. "preflop") and spec format (e.g. "Preflop")
 // by normalizing to lowercase before matching.
@@ -433,18 +553,46 @@ func isSpectatorLog(hands []Hand) bool {
 
 // ReadJSONL reads JSONL (JSON Lines) format with multiple OHH spec hands
 // Each line should contain a complete OHH spec format JSON object
+// The input is read one line at a time, so only the current line is held in memory
 func ReadJSONL(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
-	data, err := io.ReadAll(r)
+	result, err := readJSONL(r, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read JSONL: %w", err)
+		return nil, err
+	}
+	return convertReadResult(result, opts)
+}
+
+// readJSONL reads OHH JSON Lines into hands
+func readJSONL(r io.Reader, opts ConvertOptions) (*ReadResult, error) {
+	br := bufio.NewReader(r)
+	result := &ReadResult{}
+	siteNameSet := false
+
+	// skipLine records a line that cannot be decoded or converted, with its line number in the detail
+	skipLine := func(line int, err error) {
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			parseErr = &ParseError{Kind: ParseErrorJSON, Err: err}
+		}
+		parseErr.Line = line
+		result.SkippedHands++
+		result.SkippedHandsInfo = append(result.SkippedHandsInfo, SkippedHandInfo{
+			HandNumber: parseErr.HandNumber,
+			Reason:     SkipReasonInvalidInput,
+			Detail:     parseErr.Error(),
+		})
 	}
 
-	lines := strings.Split(string(data), "\n")
-	var allHands []Hand
-	totalSkipped := 0
+	for lineNum := 0; ; lineNum++ {
+		rawLine, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read JSONL: %w", err)
+		}
+		if err == io.EOF && rawLine == "" {
+			break
+		}
 
-	for lineNum, line := range lines {
-		line = strings.TrimSpace(line)
+		line := strings.TrimSpace(rawLine)
 		if line == "" {
 			continue
 		}
@@ -453,7 +601,7 @@ func ReadJSONL(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
 		var formatCheck map[string]interface{}
 		if err := json.Unmarshal([]byte(line), &formatCheck); err != nil {
 			// Skip invalid JSON lines
-			totalSkipped++
+			skipLine(lineNum+1, fmt.Errorf("failed to decode OHH JSON: %w", err))
 			continue
 		}
 
@@ -461,80 +609,73 @@ func ReadJSONL(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
 		if _, hasOHH := formatCheck["ohh"]; hasOHH {
 			var specFormat OHHSpecFormat
 			if err := json.Unmarshal([]byte(line), &specFormat); err != nil {
-				totalSkipped++
+				skipLine(lineNum+1, fmt.Errorf("failed to decode OHH spec JSON: %w", err))
 				continue
 			}
 
 			// Set time location from first hand
-			if opts.TimeLocation == nil {
-				opts.TimeLocation = specFormat.OHH.StartDateUTC.Location()
+			if result.TimeLocation == nil {
+				result.TimeLocation = specFormat.OHH.StartDateUTC.Location()
 			}
 
-			// Set site name from OHH spec if not already specified
-			if opts.SiteName == "" {
-				if specFormat.OHH.SiteName != "" {
-					opts.SiteName = specFormat.OHH.SiteName
-				} else {
-					opts.SiteName = "PokerStars"
-				}
+			// Set site name from the first OHH spec hand
+			if !siteNameSet {
+				result.SiteName = specFormat.OHH.SiteName
+				siteNameSet = true
 			}
 
-			hand, err := convertOHHSpecToHand(specFormat.OHH, opts)
+			hand, warnings, err := convertOHHSpecToHand(specFormat.OHH, opts)
 			if err != nil {
-				totalSkipped++
+				skipLine(lineNum+1, err)
 				continue
 			}
 
 			// Check if this hand has hero cards
 			if len(hand.HeroCards) == 0 {
 				// Skip spectator hands in JSONL
-				totalSkipped++
+				result.SkippedHands++
 				continue
 			}
 
-			allHands = append(allHands, hand)
+			if result.HeroName == "" {
+				result.HeroName = ohhHeroName(specFormat.OHH)
+			}
+			result.Hands = append(result.Hands, hand)
+			result.Warnings = append(result.Warnings, warnings...)
+			result.checks = checkHand(result.checks, hand, func() []string { return []string{line} })
 		} else {
 			// Try simplified format
 			var ohhHand OHHHand
 			if err := json.Unmarshal([]byte(line), &ohhHand); err != nil {
-				totalSkipped++
+				skipLine(lineNum+1, fmt.Errorf("failed to decode OHH JSON: %w", err))
 				continue
 			}
 
-			if opts.TimeLocation == nil {
-				opts.TimeLocation = ohhHand.StartTime.Location()
+			if result.TimeLocation == nil {
+				result.TimeLocation = ohhHand.StartTime.Location()
 			}
 
-			hand, err := convertOHHHandToHand(ohhHand)
+			hand, warnings, err := convertOHHHandToHand(ohhHand)
 			if err != nil {
-				totalSkipped++
+				skipLine(lineNum+1, &ParseError{Kind: ParseErrorInvalidHand, HandNumber: ohhHand.HandNumber, Err: err})
 				continue
 			}
 
 			// Check if this hand has hero cards
 			if len(hand.HeroCards) == 0 {
-				totalSkipped++
+				result.SkippedHands++
 				continue
 			}
 
-			allHands = append(allHands, hand)
-		}
-
-		// Limit the number of lines processed to avoid excessive memory usage
-		if lineNum > maxJSONLLines {
-			break
+			result.Hands = append(result.Hands, hand)
+			result.Warnings = append(result.Warnings, warnings...)
+			result.checks = checkHand(result.checks, hand, func() []string { return []string{line} })
 		}
 	}
 
-	if len(allHands) == 0 {
+	if len(result.Hands) == 0 {
 		return nil, ErrSpectatorLog
 	}
 
-	// Convert to HH format
-	hh := convertHandsToHH(allHands, opts)
-
-	return &ConvertResult{
-		HH:           []byte(hh),
-		SkippedHands: totalSkipped,
-	}, nil
+	return result, nil
 }
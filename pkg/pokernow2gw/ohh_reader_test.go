@@ -165,7 +165,7 @@ func TestConvertOHHHandToHand(t *testing.T) {
 		},
 	}
 
-	hand, err := convertOHHHandToHand(ohhHand)
+	hand, _, err := convertOHHHandToHand(ohhHand)
 	if err != nil {
 		t.Fatalf("convertOHHHandToHand() error = %v", err)
 	}
@@ -228,6 +228,10 @@ func TestConvertOHHActionType(t *testing.T) {
 		{"postSB", ActionPostSB, false},
 		{"postBB", ActionPostBB, false},
 		{"postAnte", ActionPostAnte, false},
+		{"postStraddle", ActionPostStraddle, false},
+		{"Straddle", ActionPostStraddle, false},
+		{"Post Dead", ActionPostDeadSB, false},
+		{"postDeadBB", ActionPostDeadBB, false},
 		{"show", ActionShow, false},
 		{"collect", ActionCollect, false},
 		{"uncalled", ActionUncalled, false},
@@ -535,24 +539,65 @@ func TestReadJSONL(t *testing.T) {
 
 func TestReadOHHSpec_BetTypeValidation(t *testing.T) {
 	tests := []struct {
-		name    string
-		betType string
-		wantErr bool
+		name       string
+		gameType   string
+		betType    string
+		cards      string
+		wantErr    bool
+		wantHeader string
 	}{
 		{
-			name:    "NL bet type (valid)",
-			betType: "NL",
-			wantErr: false,
+			name:       "NL bet type (valid)",
+			gameType:   "Holdem",
+			betType:    "NL",
+			cards:      `"Ah", "Kh"`,
+			wantErr:    false,
+			wantHeader: "Hold'em No Limit",
 		},
 		{
-			name:    "PL bet type (invalid)",
-			betType: "PL",
-			wantErr: true,
+			name:     "PL bet type (invalid)",
+			gameType: "Holdem",
+			betType:  "PL",
+			cards:    `"Ah", "Kh"`,
+			wantErr:  true,
 		},
 		{
-			name:    "FL bet type (invalid)",
-			betType: "FL",
-			wantErr: true,
+			name:     "FL bet type (invalid)",
+			gameType: "Holdem",
+			betType:  "FL",
+			cards:    `"Ah", "Kh"`,
+			wantErr:  true,
+		},
+		{
+			name:       "PL Omaha (valid)",
+			gameType:   "Omaha",
+			betType:    "PL",
+			cards:      `"Ah", "Kh", "Qd", "Jd"`,
+			wantErr:    false,
+			wantHeader: "Omaha Pot Limit",
+		},
+		{
+			name:       "PL Omaha 5 cards (valid)",
+			gameType:   "Omaha",
+			betType:    "PL",
+			cards:      `"Ah", "Kh", "Qd", "Jd", "9c"`,
+			wantErr:    false,
+			wantHeader: "5 Card Omaha Pot Limit",
+		},
+		{
+			name:       "PL Omaha 6 cards (valid)",
+			gameType:   "Omaha",
+			betType:    "PL",
+			cards:      `"Ah", "Kh", "Qd", "Jd", "9c", "8c"`,
+			wantErr:    false,
+			wantHeader: "6 Card Omaha Pot Limit",
+		},
+		{
+			name:     "NL Omaha (invalid)",
+			gameType: "Omaha",
+			betType:  "NL",
+			cards:    `"Ah", "Kh", "Qd", "Jd"`,
+			wantErr:  true,
 		},
 	}
 
@@ -565,7 +610,7 @@ func TestReadOHHSpec_BetTypeValidation(t *testing.T) {
     "internal_version": "1.0.0",
     "network_name": "Test",
     "site_name": "Test Site",
-    "game_type": "Holdem",
+    "game_type": "` + tt.gameType + `",
     "table_name": "test-table",
     "table_size": 2,
     "game_number": "1",
@@ -586,7 +631,7 @@ func TestReadOHHSpec_BetTypeValidation(t *testing.T) {
         "name": "Hero",
         "seat": 1,
         "starting_stack": 100,
-        "cards": ["Ah", "Kh"]
+        "cards": [` + tt.cards + `]
       }
     ],
     "rounds": [],
@@ -599,9 +644,13 @@ func TestReadOHHSpec_BetTypeValidation(t *testing.T) {
 				SiteName: "PokerStars",
 			}
 
-			_, err := ReadOHH(strings.NewReader(input), opts)
+			result, err := ReadOHH(strings.NewReader(input), opts)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("ReadOHH() with bet_type=%s, error = %v, wantErr %v", tt.betType, err, tt.wantErr)
+				t.Errorf("ReadOHH() with game_type=%s bet_type=%s, error = %v, wantErr %v", tt.gameType, tt.betType, err, tt.wantErr)
+				return
+			}
+			if tt.wantHeader != "" && !strings.Contains(string(result.HH), tt.wantHeader) {
+				t.Errorf("ReadOHH() output should contain %q, got:\n%s", tt.wantHeader, result.HH)
 			}
 		})
 	}
@@ -972,6 +1021,26 @@ func TestReadOHHSpec_USDCurrency_HasDollarSign(t *testing.T) {
 	}
 }
 
+func TestReadJSONL_ReadsEveryLine(t *testing.T) {
+	hand := `{"ohh":{"game_type":"Holdem","game_number":"1","bet_limit":{"bet_type":"NL"},"hero_player_id":1,"small_blind_amount":1,"big_blind_amount":2,` +
+		`"players":[{"id":1,"name":"Hero","seat":1,"starting_stack":100,"cards":["Ah","Kh"]},{"id":2,"name":"Villain","seat":2,"starting_stack":100}],` +
+		`"rounds":[{"street":"Preflop","actions":[{"player_id":1,"action":"Post SB","amount":1},{"player_id":2,"action":"Post BB","amount":2},{"player_id":1,"action":"Fold"}]}],` +
+		`"pots":[{"amount":2,"player_wins":[{"player_id":2,"win_amount":2}]}]}}` + "\n"
+
+	// JSONL is streamed, so long sessions are read to the end
+	const lines = 10010
+	result, err := readJSONL(strings.NewReader(strings.Repeat(hand, lines)), ConvertOptions{HeroName: "Hero"})
+	if err != nil {
+		t.Fatalf("readJSONL() error = %v", err)
+	}
+	if len(result.Hands) != lines {
+		t.Errorf("read %d hands, want %d", len(result.Hands), lines)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Warnings = %+v, want none", result.Warnings)
+	}
+}
+
 func TestIsJSONLFormat(t *testing.T) {
 	tests := []struct {
 		name  string
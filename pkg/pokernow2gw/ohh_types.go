@@ -54,7 +54,7 @@ type OHHBoard struct {
 // OHHAction represents a player action
 type OHHAction struct {
 	Player     string  `json:"player"`
-	ActionType string  `json:"actionType"` // fold, check, call, bet, raise, postSB, postBB, postAnte, show, collect, uncalled
+	ActionType string  `json:"actionType"` // fold, check, call, bet, raise, postSB, postBB, postAnte, postStraddle, postDeadSB, postDeadBB, show, collect, uncalled
 	Amount     float64 `json:"amount,omitempty"`
 	Street     string  `json:"street"` // preflop, flop, turn, river, showdown
 	IsAllIn    bool    `json:"isAllIn,omitempty"`
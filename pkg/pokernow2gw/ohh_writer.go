@@ -0,0 +1,258 @@
+package pokernow2gw
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	// ohhSpecVersion is the Open Hand History spec version written by the OHH writer
+	ohhSpecVersion = "1.4.6"
+	// ohhInternalVersion is the version of pokernow2gw's OHH output
+	ohhInternalVersion = "1.0.0"
+)
+
+// WriteOHH writes hands as Open Hand History JSON
+// A single hand is a plain OHH document; several hands are written one after another
+// separated by a blank line, as in .ohh files exported by poker sites.
+func WriteOHH(w io.Writer, hands []Hand, opts ConvertOptions) error {
+	for i, hand := range hands {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return fmt.Errorf("failed to write OHH JSON: %w", err)
+			}
+		}
+		data, err := json.MarshalIndent(ConvertHandToOHH(hand, opts), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode hand #%s as OHH JSON: %w", hand.HandNumber, err)
+		}
+		data = append(data, '\n')
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write OHH JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteOHHJSONL writes hands as Open Hand History JSON Lines, one hand per line
+// The output can be read back with ReadJSONL.
+func WriteOHHJSONL(w io.Writer, hands []Hand, opts ConvertOptions) error {
+	enc := json.NewEncoder(w)
+	for _, hand := range hands {
+		if err := enc.Encode(ConvertHandToOHH(hand, opts)); err != nil {
+			return fmt.Errorf("failed to write hand #%s as OHH JSONL: %w", hand.HandNumber, err)
+		}
+	}
+	return nil
+}
+
+// ConvertHandToOHH converts an internal Hand to the OHH spec format
+// Amounts follow the spec: calls are the chips added by the call, while bets, raises and blinds are
+// the street totals PokerNow logs. Uncalled bets are not actions in OHH; the pots only hold
+// what was collected. Only the first run of the board is written when the hand was run more than once.
+func ConvertHandToOHH(hand Hand, opts ConvertOptions) OHHSpecFormat {
+	// OHH identifies players by number; PokerNow IDs are kept in _uid
+	playerIDs := make(map[string]int, len(hand.Players))
+	players := make([]OHHSpecPlayer, 0, len(hand.Players))
+	heroID := 0
+	for i, p := range hand.Players {
+		id := i + 1
+		playerIDs[p.key()] = id
+
+		cards, _ := shownHand(hand, p.key())
+		if p.DisplayName == opts.HeroName && len(hand.HeroCards) > 0 {
+			heroID = id
+			cards = hand.HeroCards
+		}
+
+		uid := p.ID
+		if uid == "" && p.Name != p.DisplayName {
+			uid = extractPlayerID(p.Name)
+		}
+		players = append(players, OHHSpecPlayer{
+			ID:            id,
+			Name:          p.DisplayName,
+			Seat:          p.SeatNumber,
+			StartingStack: p.Stack,
+			Cards:         cards,
+			UID:           uid,
+		})
+	}
+
+	siteName := hand.SiteName
+	if siteName == "" {
+		siteName = "PokerNow"
+	}
+	currency := hand.Currency
+	if currency == "" {
+		currency = "Chips"
+		if opts.GameType == GameTypeCash {
+			currency = "USD"
+		}
+	}
+	gameType := "Holdem"
+	if hand.Game != PokerGameHoldem {
+		gameType = "Omaha"
+	}
+	betType := "NL"
+	if hand.Limit == BetLimitPotLimit {
+		betType = "PL"
+	}
+
+	return OHHSpecFormat{
+		ID: hand.HandID,
+		OHH: OHHSpec{
+			SpecVersion:      ohhSpecVersion,
+			InternalVersion:  ohhInternalVersion,
+			NetworkName:      siteName,
+			SiteName:         siteName,
+			GameType:         gameType,
+			TableName:        hand.TableName,
+			TableSize:        len(hand.Players),
+			GameNumber:       hand.HandID,
+			StartDateUTC:     hand.StartTime.UTC(),
+			Currency:         currency,
+			AnteAmount:       hand.Ante,
+			SmallBlindAmount: hand.SmallBlind,
+			BigBlindAmount:   hand.BigBlind,
+			BetLimit:         OHHBetLimit{BetType: betType},
+			DealerSeat:       getDealerSeat(hand),
+			HeroPlayerID:     heroID,
+			Players:          players,
+			Rounds:           ohhRounds(hand, playerIDs),
+			Pots:             ohhPots(hand, playerIDs, opts),
+		},
+		CreatedAt: hand.StartTime.UTC(),
+	}
+}
+
+// ohhRounds converts the actions of a hand to OHH rounds, one per street that was dealt
+func ohhRounds(hand Hand, playerIDs map[string]int) []OHHRound {
+	streets := []struct {
+		street Street
+		name   string
+		cards  []string
+		dealt  bool
+	}{
+		{StreetPreflop, "Preflop", []string{}, true},
+		{StreetFlop, "Flop", hand.Board.Flop, len(hand.Board.Flop) > 0},
+		{StreetTurn, "Turn", []string{hand.Board.Turn}, hand.Board.Turn != ""},
+		{StreetRiver, "River", []string{hand.Board.River}, hand.Board.River != ""},
+		{StreetShowdown, "Showdown", []string{}, false},
+	}
+
+	var rounds []OHHRound
+	actionNumber := 0
+	for _, s := range streets {
+		// Calls are converted from street totals to the chips added
+		committed := make(map[string]float64)
+		actions := []OHHRoundAction{}
+		for _, action := range hand.Actions {
+			if action.Street != s.street {
+				continue
+			}
+			name, ok := ohhActionName(action.ActionType)
+			if !ok {
+				continue
+			}
+			amount := action.Amount
+			switch action.ActionType {
+			case ActionCall:
+				amount = roundAmount(action.Amount - committed[action.playerKey()])
+				committed[action.playerKey()] = action.Amount
+			case ActionPostSB, ActionPostBB, ActionPostStraddle, ActionPostDeadBB, ActionBet, ActionRaise:
+				committed[action.playerKey()] = action.Amount
+			}
+			actionNumber++
+			actions = append(actions, OHHRoundAction{
+				ActionNumber: actionNumber,
+				PlayerID:     playerIDs[action.playerKey()],
+				Action:       name,
+				Amount:       amount,
+				IsAllIn:      action.IsAllIn,
+			})
+		}
+		if !s.dealt && len(actions) == 0 {
+			continue
+		}
+		rounds = append(rounds, OHHRound{
+			ID:      len(rounds),
+			Street:  s.name,
+			Cards:   s.cards,
+			Actions: actions,
+		})
+	}
+	return rounds
+}
+
+// ohhActionName returns the OHH spec name of an action
+// Collections and uncalled bets are not OHH actions (they are covered by the pots).
+func ohhActionName(actionType ActionType) (string, bool) {
+	switch actionType {
+	case ActionFold:
+		return "Fold", true
+	case ActionCheck:
+		return "Check", true
+	case ActionCall:
+		return "Call", true
+	case ActionBet:
+		return "Bet", true
+	case ActionRaise:
+		return "Raise", true
+	case ActionPostSB:
+		return "Post SB", true
+	case ActionPostBB:
+		return "Post BB", true
+	case ActionPostAnte:
+		return "Post Ante", true
+	case ActionPostStraddle:
+		return "Straddle", true
+	case ActionPostDeadSB:
+		return "Post Dead", true
+	case ActionPostDeadBB:
+		return "Post Extra Blind", true
+	case ActionShow:
+		return "Shows Cards", true
+	default:
+		return "", false
+	}
+}
+
+// ohhPots converts the collections of a hand to OHH pots
+// Main and side pots are written separately when the collections can be matched to them;
+// otherwise everything is one pot. The rake is charged to the first pot.
+func ohhPots(hand Hand, playerIDs map[string]int, opts ConvertOptions) []OHHPot {
+	totalPot := calculateTotalPot(hand)
+	rake := calculateRake(totalPot, hand.BigBlind, opts.RakePercent, opts.RakeCapBB)
+
+	pots := buildPots(hand)
+	if len(pots) < 2 || potTotal(pots) != totalPot {
+		pots = nil
+	}
+	winnerPots := assignWinnerPots(hand, pots)
+	if winnerPots == nil {
+		pots = []pot{{Amount: totalPot}}
+	}
+
+	ohhPots := make([]OHHPot, len(pots))
+	for i, p := range pots {
+		ohhPots[i] = OHHPot{Number: i, Amount: p.Amount, PlayerWins: []OHHPlayerWin{}}
+	}
+	ohhPots[0].Rake = rake
+
+	for i, winner := range hand.Winners {
+		if winner.Amount <= 0 {
+			continue
+		}
+		index := 0
+		if winnerPots != nil {
+			index = winnerPots[i]
+		}
+		ohhPots[index].PlayerWins = append(ohhPots[index].PlayerWins, OHHPlayerWin{
+			PlayerID:  playerIDs[winner.playerKey()],
+			WinAmount: winner.Amount,
+		})
+	}
+	return ohhPots
+}
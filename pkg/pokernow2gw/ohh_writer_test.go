@@ -0,0 +1,255 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertHandToOHH(t *testing.T) {
+	hand := Hand{
+		HandNumber: "1",
+		HandID:     "1",
+		Dealer:     "alice",
+		Players: []Player{
+			{SeatNumber: 1, Name: "alice @ A1", DisplayName: "alice", Stack: 100},
+			{SeatNumber: 2, Name: "bob @ B2", DisplayName: "bob", Stack: 200},
+		},
+		Actions: []Action{
+			{Player: "alice", ActionType: ActionPostSB, Amount: 1, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionPostBB, Amount: 2, Street: StreetPreflop},
+			{Player: "alice", ActionType: ActionRaise, Amount: 6, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionCall, Amount: 6, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionCheck, Street: StreetFlop},
+			{Player: "alice", ActionType: ActionBet, Amount: 8, Street: StreetFlop},
+			{Player: "bob", ActionType: ActionFold, Street: StreetFlop},
+			{Player: "alice", ActionType: ActionUncalled, Amount: 8, Street: StreetFlop},
+			{Player: "alice", ActionType: ActionCollect, Amount: 12, Street: StreetShowdown},
+		},
+		Board:      Board{Flop: []string{"Ah", "Kd", "2c"}},
+		StartTime:  time.Date(2025, 11, 15, 5, 0, 0, 0, time.UTC),
+		SmallBlind: 1,
+		BigBlind:   2,
+		Winners:    []Winner{{Player: "alice", Amount: 12}},
+		HeroCards:  []string{"Qs", "Qh"},
+	}
+
+	got := ConvertHandToOHH(hand, ConvertOptions{HeroName: "alice", GameType: GameTypeCash, RakePercent: 5, RakeCapBB: 4})
+
+	if got.OHH.HeroPlayerID != 1 || got.OHH.DealerSeat != 1 || got.OHH.Currency != "USD" || got.OHH.BetLimit.BetType != "NL" {
+		t.Errorf("unexpected header: hero=%d dealer=%d currency=%q bet_type=%q",
+			got.OHH.HeroPlayerID, got.OHH.DealerSeat, got.OHH.Currency, got.OHH.BetLimit.BetType)
+	}
+
+	wantPlayers := []OHHSpecPlayer{
+		{ID: 1, Name: "alice", Seat: 1, StartingStack: 100, Cards: []string{"Qs", "Qh"}, UID: "A1"},
+		{ID: 2, Name: "bob", Seat: 2, StartingStack: 200, UID: "B2"},
+	}
+	if diff := cmp.Diff(wantPlayers, got.OHH.Players); diff != "" {
+		t.Errorf("Players mismatch (-want +got):\n%s", diff)
+	}
+
+	wantRounds := []OHHRound{
+		{ID: 0, Street: "Preflop", Cards: []string{}, Actions: []OHHRoundAction{
+			{ActionNumber: 1, PlayerID: 1, Action: "Post SB", Amount: 1},
+			{ActionNumber: 2, PlayerID: 2, Action: "Post BB", Amount: 2},
+			{ActionNumber: 3, PlayerID: 1, Action: "Raise", Amount: 6},
+			{ActionNumber: 4, PlayerID: 2, Action: "Call", Amount: 4},
+		}},
+		{ID: 1, Street: "Flop", Cards: []string{"Ah", "Kd", "2c"}, Actions: []OHHRoundAction{
+			{ActionNumber: 5, PlayerID: 2, Action: "Check"},
+			{ActionNumber: 6, PlayerID: 1, Action: "Bet", Amount: 8},
+			{ActionNumber: 7, PlayerID: 2, Action: "Fold"},
+		}},
+	}
+	if diff := cmp.Diff(wantRounds, got.OHH.Rounds); diff != "" {
+		t.Errorf("Rounds mismatch (-want +got):\n%s", diff)
+	}
+
+	wantPots := []OHHPot{
+		{Number: 0, Amount: 12, Rake: 0.6, PlayerWins: []OHHPlayerWin{{PlayerID: 1, WinAmount: 12}}},
+	}
+	if diff := cmp.Diff(wantPots, got.OHH.Pots); diff != "" {
+		t.Errorf("Pots mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertHandToOHH_ReadBack(t *testing.T) {
+	// Calls on several streets, so the chips added differ from the street totals
+	actions := []Action{
+		{Player: "alice", PlayerID: "A1", ActionType: ActionPostSB, Amount: 50, Street: StreetPreflop},
+		{Player: "bob", PlayerID: "B2", ActionType: ActionPostBB, Amount: 100, Street: StreetPreflop},
+		{Player: "alice", PlayerID: "A1", ActionType: ActionRaise, Amount: 300, Street: StreetPreflop},
+		{Player: "bob", PlayerID: "B2", ActionType: ActionCall, Amount: 300, Street: StreetPreflop},
+		{Player: "bob", PlayerID: "B2", ActionType: ActionBet, Amount: 200, Street: StreetFlop},
+		{Player: "alice", PlayerID: "A1", ActionType: ActionRaise, Amount: 600, Street: StreetFlop},
+		{Player: "bob", PlayerID: "B2", ActionType: ActionCall, Amount: 600, Street: StreetFlop},
+		{Player: "alice", PlayerID: "A1", ActionType: ActionBet, Amount: 500, Street: StreetTurn},
+		{Player: "bob", PlayerID: "B2", ActionType: ActionFold, Street: StreetTurn},
+		{Player: "alice", PlayerID: "A1", ActionType: ActionUncalled, Amount: 500, Street: StreetTurn},
+	}
+	hand := Hand{
+		HandNumber: "1",
+		HandID:     "1",
+		Dealer:     "alice",
+		Players: []Player{
+			{SeatNumber: 1, Name: "alice @ A1", DisplayName: "alice", ID: "A1", Stack: 5000},
+			{SeatNumber: 2, Name: "bob @ B2", DisplayName: "bob", ID: "B2", Stack: 5000},
+		},
+		Actions:    actions,
+		Board:      Board{Flop: []string{"Ah", "Kd", "2c"}, Turn: "7s"},
+		StartTime:  time.Date(2025, 11, 15, 5, 0, 0, 0, time.UTC),
+		SmallBlind: 50,
+		BigBlind:   100,
+		Winners:    []Winner{{Player: "alice", PlayerID: "A1", Amount: 1800}},
+		HeroCards:  []string{"Qs", "Qh"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOHHJSONL(&buf, []Hand{hand}, ConvertOptions{HeroName: "alice"}); err != nil {
+		t.Fatalf("WriteOHHJSONL() failed: %v", err)
+	}
+	result, err := readJSONL(&buf, ConvertOptions{HeroName: "alice"})
+	if err != nil {
+		t.Fatalf("readJSONL() failed: %v", err)
+	}
+	if len(result.Hands) != 1 {
+		t.Fatalf("read back %d hands, want 1", len(result.Hands))
+	}
+
+	got := result.Hands[0]
+	if diff := cmp.Diff(actions, got.Actions); diff != "" {
+		t.Errorf("Actions mismatch (-want +got):\n%s", diff)
+	}
+	if err := ValidateHand(got); err != nil {
+		t.Errorf("hand read back does not add up: %v", err)
+	}
+}
+
+func TestConvertHandToOHH_SidePots(t *testing.T) {
+	hand := Hand{
+		HandID: "1",
+		Players: []Player{
+			{SeatNumber: 1, DisplayName: "alice", Stack: 100},
+			{SeatNumber: 2, DisplayName: "bob", Stack: 300},
+			{SeatNumber: 3, DisplayName: "carol", Stack: 500},
+		},
+		Actions: []Action{
+			{Player: "bob", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+			{Player: "carol", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+			{Player: "alice", ActionType: ActionRaise, Amount: 100, Street: StreetPreflop, IsAllIn: true},
+			{Player: "bob", ActionType: ActionRaise, Amount: 300, Street: StreetPreflop, IsAllIn: true},
+			{Player: "carol", ActionType: ActionCall, Amount: 300, Street: StreetPreflop},
+			{Player: "alice", ActionType: ActionShow, Street: StreetShowdown},
+			{Player: "carol", ActionType: ActionShow, Street: StreetShowdown},
+		},
+		Winners: []Winner{
+			{Player: "alice", Amount: 300, HandCards: []string{"Ah", "As"}},
+			{Player: "carol", Amount: 400, HandCards: []string{"Qd", "Qc"}},
+		},
+	}
+
+	got := ConvertHandToOHH(hand, ConvertOptions{})
+
+	wantPots := []OHHPot{
+		{Number: 0, Amount: 300, PlayerWins: []OHHPlayerWin{{PlayerID: 1, WinAmount: 300}}},
+		{Number: 1, Amount: 400, PlayerWins: []OHHPlayerWin{{PlayerID: 3, WinAmount: 400}}},
+	}
+	if diff := cmp.Diff(wantPots, got.OHH.Pots); diff != "" {
+		t.Errorf("Pots mismatch (-want +got):\n%s", diff)
+	}
+
+	// Shown cards are attached to the players and the showdown gets its own round
+	if diff := cmp.Diff([]string{"Qd", "Qc"}, got.OHH.Players[2].Cards); diff != "" {
+		t.Errorf("carol's cards mismatch (-want +got):\n%s", diff)
+	}
+	last := got.OHH.Rounds[len(got.OHH.Rounds)-1]
+	if last.Street != "Showdown" || len(last.Actions) != 2 || last.Actions[0].Action != "Shows Cards" {
+		t.Errorf("showdown round = %+v, want two \"Shows Cards\" actions", last)
+	}
+}
+
+func TestOHHOutputFormat_RoundTrip(t *testing.T) {
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,12
+"""player1 @ id1"" collected 400 from pot",2025-11-15T05:09:14.567Z,11
+"Uncalled bet of 100 returned to ""player1 @ id1""",2025-11-15T05:09:14.567Z,10
+"""player2 @ id2"" folds",2025-11-15T05:09:14.567Z,9
+"""player1 @ id1"" bets 100",2025-11-15T05:09:14.567Z,8
+"""player2 @ id2"" checks",2025-11-15T05:09:14.567Z,7
+"Flop:  [A♥, K♦, 2♣]",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" calls 200",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" raises to 200",2025-11-15T05:09:14.567Z,4
+"""player2 @ id2"" posts a big blind of 100",2025-11-15T05:09:14.567Z,3
+"""player1 @ id1"" posts a small blind of 50",2025-11-15T05:09:14.567Z,2
+"Your hand is Q♠, Q♥",2025-11-15T05:09:14.567Z,1
+"Player stacks: #1 ""player1 @ id1"" (1000) | #2 ""player2 @ id2"" (1000)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: roundtrip1) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,0`
+
+	opts := ConvertOptions{
+		HeroName:     "player1",
+		TimeLocation: time.UTC,
+		OutputFormat: OutputFormatOHHJSONL,
+	}
+	result, err := Parse(strings.NewReader(csv), opts)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	var spec OHHSpecFormat
+	if err := json.Unmarshal(bytes.TrimSpace(result.HH), &spec); err != nil {
+		t.Fatalf("output is not a single OHH JSON line: %v\n%s", err, result.HH)
+	}
+	if spec.OHH.Pots[0].Amount != 400 {
+		t.Errorf("pot amount = %v, want 400", spec.OHH.Pots[0].Amount)
+	}
+
+	// The OHH output can be converted again to PokerStars text
+	opts.OutputFormat = OutputFormatPokerStars
+	back, err := ReadJSONL(bytes.NewReader(result.HH), opts)
+	if err != nil {
+		t.Fatalf("ReadJSONL() failed: %v", err)
+	}
+	assertInOrder(t, string(back.HH), []string{
+		"Dealt to player1 [Qs Qh]\n",
+		"player1: posts small blind 50\n",
+		"player2: posts big blind 100\n",
+		"player1: raises to 200\n",
+		"player2: calls 100\n",
+		"*** FLOP *** [Ah Kd 2c]\n",
+		"player1: bets 100\n",
+		"player2: folds\n",
+		"Uncalled bet (100) returned to player1\n",
+		"player1 collected 400 from pot\n",
+		"Total pot 400 | Rake 0\n",
+	})
+}
+
+func TestWriteOHH_MultipleHands(t *testing.T) {
+	hands := []Hand{
+		{HandID: "1", Players: []Player{{SeatNumber: 1, DisplayName: "alice", Stack: 100}}},
+		{HandID: "2", Players: []Player{{SeatNumber: 1, DisplayName: "alice", Stack: 100}}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOHH(&buf, hands, ConvertOptions{}); err != nil {
+		t.Fatalf("WriteOHH() failed: %v", err)
+	}
+
+	dec := json.NewDecoder(&buf)
+	var ids []string
+	for dec.More() {
+		var spec OHHSpecFormat
+		if err := dec.Decode(&spec); err != nil {
+			t.Fatalf("failed to decode OHH document: %v", err)
+		}
+		ids = append(ids, spec.ID)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, ids); diff != "" {
+		t.Errorf("hand IDs mismatch (-want +got):\n%s", diff)
+	}
+}
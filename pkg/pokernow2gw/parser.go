@@ -2,6 +2,7 @@ package pokernow2gw
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,43 +11,66 @@ import (
 var (
 	// 正規表現パターン
 	// (id: ...) 部分はオプション
+	// ゲーム名 (No Limit Texas Hold'em, Pot Limit Omaha Hi 等) はキャプチャして parsePokerNowGame で判定
 	// dealer部分は (dealer: "...") または (dead button) をサポート
-	reStartingHand = regexp.MustCompile(`^-- starting hand #(\d+)\s+(?:\(id: ([a-z0-9]+)\)\s+)?\(No Limit Texas Hold'em\)\s+(?:\(dealer: "([^"]+)"\)|\(dead button\)) --$`)
+	reStartingHand = regexp.MustCompile(`^-- starting hand #(\d+)\s+(?:\(id: ([a-z0-9]+)\)\s+)?\(([^)]+)\)\s+(?:\(dealer: "([^"]+)"\)|\(dead button\)) --$`)
 	reEndingHand   = regexp.MustCompile(`^-- ending hand #(\d+) --$`)
+	reBlindChange  = regexp.MustCompile(`^The game's (small blind|big blind|ante) was changed from (\d+(?:\.\d+)?) to (\d+(?:\.\d+)?)\.$`)
 	rePlayerStacks = regexp.MustCompile(`Player stacks: (.+)$`)
 	// Allow quotes in player names by using non-greedy match up to " (
-	rePlayerStack = regexp.MustCompile(`#(\d+) "(.+?)" \((\d+)\)`)
+	rePlayerStack = regexp.MustCompile(`#(\d+) "(.+?)" \((\d+(?:\.\d+)?)\)`)
 	reYourHand    = regexp.MustCompile(`^Your hand is (.+)$`)
-	reAnte        = regexp.MustCompile(`^"([^"]+)" posts an ante of (\d+)$`)
-	reSmallBlind  = regexp.MustCompile(`^"([^"]+)" posts a small blind of (\d+)$`)
-	reBigBlind    = regexp.MustCompile(`^"([^"]+)" posts a big blind of (\d+)$`)
+	reAnte        = regexp.MustCompile(`^"([^"]+)" posts an ante of (\d+(?:\.\d+)?)$`)
+	reSmallBlind  = regexp.MustCompile(`^"([^"]+)" posts a small blind of (\d+(?:\.\d+)?)$`)
+	reBigBlind    = regexp.MustCompile(`^"([^"]+)" posts a big blind of (\d+(?:\.\d+)?)$`)
+	reStraddle    = regexp.MustCompile(`^"([^"]+)" posts a straddle of (\d+(?:\.\d+)?)$`)
+	reDeadSB      = regexp.MustCompile(`^"([^"]+)" posts a miss(?:ing|ed) small blind of (\d+(?:\.\d+)?)$`)
+	reDeadBB      = regexp.MustCompile(`^"([^"]+)" posts a miss(?:ing|ed) big blind of (\d+(?:\.\d+)?)$`)
 	reFolds       = regexp.MustCompile(`^"([^"]+)" folds$`)
 	reChecks      = regexp.MustCompile(`^"([^"]+)" checks$`)
-	reCalls       = regexp.MustCompile(`^"([^"]+)" calls (\d+)$`)
-	reCallsAllIn  = regexp.MustCompile(`^"([^"]+)" calls (\d+) and go all in$`)
-	reBets        = regexp.MustCompile(`^"([^"]+)" bets (\d+)$`)
-	reBetsAllIn   = regexp.MustCompile(`^"([^"]+)" bets (\d+) and go all in$`)
-	reRaises      = regexp.MustCompile(`^"([^"]+)" raises to (\d+)$`)
-	reRaisesAllIn = regexp.MustCompile(`^"([^"]+)" raises to (\d+) and go all in$`)
+	reCalls       = regexp.MustCompile(`^"([^"]+)" calls (\d+(?:\.\d+)?)$`)
+	reCallsAllIn  = regexp.MustCompile(`^"([^"]+)" calls (\d+(?:\.\d+)?) and go all in$`)
+	reBets        = regexp.MustCompile(`^"([^"]+)" bets (\d+(?:\.\d+)?)$`)
+	reBetsAllIn   = regexp.MustCompile(`^"([^"]+)" bets (\d+(?:\.\d+)?) and go all in$`)
+	reRaises      = regexp.MustCompile(`^"([^"]+)" raises to (\d+(?:\.\d+)?)$`)
+	reRaisesAllIn = regexp.MustCompile(`^"([^"]+)" raises to (\d+(?:\.\d+)?) and go all in$`)
 	reFlop        = regexp.MustCompile(`^Flop:\s+\[([^\]]+)\]$`)
 	reTurn        = regexp.MustCompile(`^Turn: [^[]+\[([^\]]+)\]$`)
 	reRiver       = regexp.MustCompile(`^River: [^[]+\[([^\]]+)\]$`)
-	reShows       = regexp.MustCompile(`^"([^"]+)" shows a (.+)\.$`)
-	reCollected   = regexp.MustCompile(`^"([^"]+)" collected (\d+) from pot`)
-	reUncalled    = regexp.MustCompile(`^Uncalled bet of (\d+) returned to "([^"]+)"$`)
+	// Run it twice: "Flop (second run): [..]", "Turn (second run): .. [..]", "River (second run): .. [..]"
+	reFlopRun   = regexp.MustCompile(`^Flop \((\w+) run\):\s+\[([^\]]+)\]$`)
+	reTurnRun   = regexp.MustCompile(`^Turn \((\w+) run\): [^[]+\[([^\]]+)\]$`)
+	reRiverRun  = regexp.MustCompile(`^River \((\w+) run\): [^[]+\[([^\]]+)\]$`)
+	reShows     = regexp.MustCompile(`^"([^"]+)" shows a (.+)\.$`)
+	reCollected = regexp.MustCompile(`^"([^"]+)" collected (\d+(?:\.\d+)?) from pot`)
+	// Made hand following reCollected, e.g. " with Two Pair, K's & 9's (combination: K♠, K♣, 9♣, 9♥, 10♠)"
+	reCollectedWith = regexp.MustCompile(`^ with (.+?)(?: \(combination: [^)]*\))?$`)
+	reOmahaGame     = regexp.MustCompile(`^Pot Limit Omaha(?: Hi)?(?: \(?([456]) Cards?\)?)?$`)
+	reUncalled      = regexp.MustCompile(`^Uncalled bet of (\d+(?:\.\d+)?) returned to "([^"]+)"$`)
+	// Logged before the blinds when nobody owes the small blind (dead small blind rule)
+	reNoSmallBlind = regexp.MustCompile(`^Dead Small Blind$`)
 )
 
 // parseContext holds the mutable state passed to each log handler during parsing.
 type parseContext struct {
-	currentStreet   *Street
-	currentHand     **Hand
-	handStartIndex  *int
-	entries         []LogEntry
-	entryIndex      int
-	opts            ConvertOptions
-	skippedHands    *int
-	skippedInfo     *[]SkippedHandInfo
-	extractRawInput func(startIdx, endIdx int) []string
+	currentStreet *Street
+	currentHand   **Hand
+	entry         LogEntry // the entry being handled
+	opts          ConvertOptions
+	// skipHand drops the current hand; its raw input is collected until the hand ends
+	skipHand func(reason SkipReason, detail string, playerCount int)
+	// warn records a warning for the current hand, kept only if the hand is converted
+	warn func(w Warning)
+}
+
+// parseError returns a ParseError at the entry being handled
+func (ctx *parseContext) parseError(kind ParseErrorKind, err error) error {
+	return &ParseError{Kind: kind, Order: ctx.entry.Order, HandNumber: (*ctx.currentHand).HandNumber, Err: err}
+}
+
+// amountError returns the error for an amount in the entry being handled that is not a number
+func (ctx *parseContext) amountError(what, amount string, err error) error {
+	return ctx.parseError(ParseErrorAmount, fmt.Errorf("failed to parse %s amount %q: %w", what, amount, err))
 }
 
 // logHandler maps a regex pattern to its handler function.
@@ -67,44 +91,32 @@ var logHandlers = []logHandler{
 		handle: func(matches []string, ctx *parseContext) error {
 			hand := *ctx.currentHand
 			stacksStr := matches[1]
-			players := parsePlayerStacks(stacksStr)
+			players, err := parsePlayerStacks(stacksStr)
+			if err != nil {
+				return ctx.parseError(ParseErrorAmount, err)
+			}
 			playerCount := len(players)
 
 			// Check if player count exceeds 10-max limit
 			if playerCount > 10 {
-				endIdx := findEndingHandIndex(ctx.entries, ctx.entryIndex, hand.HandNumber)
-				*ctx.skippedHands++
-				*ctx.skippedInfo = append(*ctx.skippedInfo, SkippedHandInfo{
-					HandID:      hand.HandID,
-					HandNumber:  hand.HandNumber,
-					Reason:      SkipReasonTooManyPlayers,
-					Detail:      fmt.Sprintf("Hand #%s has %d players, but GTO Wizard only supports up to 10 players", hand.HandNumber, playerCount),
-					PlayerCount: playerCount,
-					RawInput:    ctx.extractRawInput(*ctx.handStartIndex, endIdx),
-				})
-				*ctx.currentHand = nil
-				*ctx.handStartIndex = -1
+				ctx.skipHand(SkipReasonTooManyPlayers,
+					fmt.Sprintf("Hand #%s has %d players, but GTO Wizard only supports up to 10 players", hand.HandNumber, playerCount),
+					playerCount)
 				return nil
 			}
 
 			// Apply player count filter based on GTO Wizard plan
 			if !ctx.opts.PlayerCountFilter.isPlayerCountAllowed(playerCount) {
-				endIdx := findEndingHandIndex(ctx.entries, ctx.entryIndex, hand.HandNumber)
-				*ctx.skippedHands++
-				*ctx.skippedInfo = append(*ctx.skippedInfo, SkippedHandInfo{
-					HandID:      hand.HandID,
-					HandNumber:  hand.HandNumber,
-					Reason:      SkipReasonFilteredOut,
-					Detail:      fmt.Sprintf("Hand #%s has %d players, which does not match the selected filter", hand.HandNumber, playerCount),
-					PlayerCount: playerCount,
-					RawInput:    ctx.extractRawInput(*ctx.handStartIndex, endIdx),
-				})
-				*ctx.currentHand = nil
-				*ctx.handStartIndex = -1
+				ctx.skipHand(SkipReasonFilteredOut,
+					fmt.Sprintf("Hand #%s has %d players, which does not match the selected filter", hand.HandNumber, playerCount),
+					playerCount)
 				return nil
 			}
 
-			hand.Players = players
+			if w, ok := seatsRenumberedWarning(hand.HandID, players); ok {
+				ctx.warn(w)
+			}
+			hand.Players = normalizePlayerSeats(players)
 			return nil
 		},
 	},
@@ -112,8 +124,10 @@ var logHandlers = []logHandler{
 	{
 		pattern: reYourHand,
 		handle: func(matches []string, ctx *parseContext) error {
+			hand := *ctx.currentHand
 			cards := parseCards(matches[1])
-			(*ctx.currentHand).HeroCards = cards
+			hand.HeroCards = cards
+			hand.Game = omahaVariantForCards(hand.Game, len(cards))
 			return nil
 		},
 	},
@@ -123,15 +137,17 @@ var logHandlers = []logHandler{
 		handle: func(matches []string, ctx *parseContext) error {
 			hand := *ctx.currentHand
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse ante amount %q in hand #%s: %w", matches[2], hand.HandNumber, err)
+				return ctx.amountError("ante", matches[2], err)
 			}
-			hand.Ante = float64(amount)
+			hand.Ante = amount
 			hand.Actions = append(hand.Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionPostAnte,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
@@ -143,15 +159,17 @@ var logHandlers = []logHandler{
 		handle: func(matches []string, ctx *parseContext) error {
 			hand := *ctx.currentHand
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse small blind amount %q in hand #%s: %w", matches[2], hand.HandNumber, err)
+				return ctx.amountError("small blind", matches[2], err)
 			}
-			hand.SmallBlind = float64(amount)
+			hand.SmallBlind = amount
 			hand.Actions = append(hand.Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionPostSB,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
@@ -163,15 +181,80 @@ var logHandlers = []logHandler{
 		handle: func(matches []string, ctx *parseContext) error {
 			hand := *ctx.currentHand
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse big blind amount %q in hand #%s: %w", matches[2], hand.HandNumber, err)
+				return ctx.amountError("big blind", matches[2], err)
 			}
-			hand.BigBlind = float64(amount)
+			hand.BigBlind = amount
 			hand.Actions = append(hand.Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionPostBB,
-				Amount:     float64(amount),
+				Amount:     amount,
+				Street:     *ctx.currentStreet,
+			})
+			return nil
+		},
+	},
+	// Straddle
+	{
+		pattern: reStraddle,
+		handle: func(matches []string, ctx *parseContext) error {
+			hand := *ctx.currentHand
+			player := extractDisplayName(matches[1])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
+			if err != nil {
+				return ctx.amountError("straddle", matches[2], err)
+			}
+			hand.Actions = append(hand.Actions, Action{
+				Player:     player,
+				PlayerID:   playerID,
+				ActionType: ActionPostStraddle,
+				Amount:     amount,
+				Street:     *ctx.currentStreet,
+			})
+			return nil
+		},
+	},
+	// Missing small blind (dead)
+	{
+		pattern: reDeadSB,
+		handle: func(matches []string, ctx *parseContext) error {
+			hand := *ctx.currentHand
+			player := extractDisplayName(matches[1])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
+			if err != nil {
+				return ctx.amountError("missing small blind", matches[2], err)
+			}
+			hand.Actions = append(hand.Actions, Action{
+				Player:     player,
+				PlayerID:   playerID,
+				ActionType: ActionPostDeadSB,
+				Amount:     amount,
+				Street:     *ctx.currentStreet,
+			})
+			return nil
+		},
+	},
+	// Missing big blind
+	{
+		pattern: reDeadBB,
+		handle: func(matches []string, ctx *parseContext) error {
+			hand := *ctx.currentHand
+			player := extractDisplayName(matches[1])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
+			if err != nil {
+				return ctx.amountError("missing big blind", matches[2], err)
+			}
+			hand.Actions = append(hand.Actions, Action{
+				Player:     player,
+				PlayerID:   playerID,
+				ActionType: ActionPostDeadBB,
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
@@ -209,13 +292,47 @@ var logHandlers = []logHandler{
 			return nil
 		},
 	},
+	// Flop of an additional run
+	{
+		pattern: reFlopRun,
+		handle: func(matches []string, ctx *parseContext) error {
+			*ctx.currentStreet = StreetFlop
+			cards := parseCards(matches[2])
+			if len(cards) >= 3 {
+				setBoardRunStreet(*ctx.currentHand, matches[1], StreetFlop, cards[:3])
+			}
+			return nil
+		},
+	},
+	// Turn of an additional run
+	{
+		pattern: reTurnRun,
+		handle: func(matches []string, ctx *parseContext) error {
+			*ctx.currentStreet = StreetTurn
+			card := convertCard(strings.TrimSpace(matches[2]))
+			setBoardRunStreet(*ctx.currentHand, matches[1], StreetTurn, []string{card})
+			return nil
+		},
+	},
+	// River of an additional run
+	{
+		pattern: reRiverRun,
+		handle: func(matches []string, ctx *parseContext) error {
+			*ctx.currentStreet = StreetRiver
+			card := convertCard(strings.TrimSpace(matches[2]))
+			setBoardRunStreet(*ctx.currentHand, matches[1], StreetRiver, []string{card})
+			return nil
+		},
+	},
 	// Folds
 	{
 		pattern: reFolds,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
+			playerID := extractPlayerID(matches[1])
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionFold,
 				Street:     *ctx.currentStreet,
 			})
@@ -227,8 +344,10 @@ var logHandlers = []logHandler{
 		pattern: reChecks,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
+			playerID := extractPlayerID(matches[1])
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionCheck,
 				Street:     *ctx.currentStreet,
 			})
@@ -240,14 +359,16 @@ var logHandlers = []logHandler{
 		pattern: reCallsAllIn,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse call all-in amount %q in hand #%s: %w", matches[2], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("call all-in", matches[2], err)
 			}
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionCall,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 				IsAllIn:    true,
 			})
@@ -259,14 +380,16 @@ var logHandlers = []logHandler{
 		pattern: reCalls,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse call amount %q in hand #%s: %w", matches[2], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("call", matches[2], err)
 			}
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionCall,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
@@ -277,14 +400,16 @@ var logHandlers = []logHandler{
 		pattern: reBetsAllIn,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse bet all-in amount %q in hand #%s: %w", matches[2], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("bet all-in", matches[2], err)
 			}
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionBet,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 				IsAllIn:    true,
 			})
@@ -296,14 +421,16 @@ var logHandlers = []logHandler{
 		pattern: reBets,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse bet amount %q in hand #%s: %w", matches[2], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("bet", matches[2], err)
 			}
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionBet,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
@@ -314,14 +441,16 @@ var logHandlers = []logHandler{
 		pattern: reRaisesAllIn,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse raise all-in amount %q in hand #%s: %w", matches[2], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("raise all-in", matches[2], err)
 			}
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionRaise,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 				IsAllIn:    true,
 			})
@@ -333,14 +462,16 @@ var logHandlers = []logHandler{
 		pattern: reRaises,
 		handle: func(matches []string, ctx *parseContext) error {
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse raise amount %q in hand #%s: %w", matches[2], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("raise", matches[2], err)
 			}
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionRaise,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
@@ -352,15 +483,19 @@ var logHandlers = []logHandler{
 		handle: func(matches []string, ctx *parseContext) error {
 			hand := *ctx.currentHand
 			player := extractDisplayName(matches[1])
+			playerID := extractPlayerID(matches[1])
 			cards := parseCards(matches[2])
+			hand.Game = omahaVariantForCards(hand.Game, len(cards))
 			hand.Actions = append(hand.Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionShow,
 				Street:     StreetShowdown,
 			})
 			// Add to winners (amount will be filled later)
 			hand.Winners = append(hand.Winners, Winner{
 				Player:    player,
+				PlayerID:  playerID,
 				HandCards: cards,
 			})
 			return nil
@@ -372,37 +507,46 @@ var logHandlers = []logHandler{
 		handle: func(matches []string, ctx *parseContext) error {
 			hand := *ctx.currentHand
 			player := extractDisplayName(matches[1])
-			amount, err := strconv.Atoi(matches[2])
+			playerID := extractPlayerID(matches[1])
+			amount, err := parseAmount(matches[2])
 			if err != nil {
-				return fmt.Errorf("failed to parse collected amount %q in hand #%s: %w", matches[2], hand.HandNumber, err)
+				return ctx.amountError("collected", matches[2], err)
 			}
 			hand.Actions = append(hand.Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionCollect,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     StreetShowdown,
 			})
-			// Update winner amount
+			handName := ""
+			if with := reCollectedWith.FindStringSubmatch(strings.TrimPrefix(ctx.entry.Entry, matches[0])); with != nil {
+				handName = pokerStarsHandName(with[1])
+			}
+			// Fill the winner entry created by "shows" if it has not collected yet.
+			// A player can collect more than once (side pots, each run of the board),
+			// so every further collection becomes its own winner entry.
 			for i := range hand.Winners {
-				if hand.Winners[i].Player == player {
-					hand.Winners[i].Amount = float64(amount)
-					break
+				if hand.Winners[i].PlayerID == playerID && hand.Winners[i].Amount == 0 {
+					hand.Winners[i].Amount = amount
+					hand.Winners[i].HandName = handName
+					return nil
 				}
 			}
-			// If winner not found in shows, add new winner
-			found := false
+			var handCards []string
 			for _, w := range hand.Winners {
-				if w.Player == player {
-					found = true
+				if w.PlayerID == playerID && len(w.HandCards) > 0 {
+					handCards = w.HandCards
 					break
 				}
 			}
-			if !found {
-				hand.Winners = append(hand.Winners, Winner{
-					Player: player,
-					Amount: float64(amount),
-				})
-			}
+			hand.Winners = append(hand.Winners, Winner{
+				Player:    player,
+				PlayerID:  playerID,
+				Amount:    amount,
+				HandCards: handCards,
+				HandName:  handName,
+			})
 			return nil
 		},
 	},
@@ -410,126 +554,264 @@ var logHandlers = []logHandler{
 	{
 		pattern: reUncalled,
 		handle: func(matches []string, ctx *parseContext) error {
-			amount, err := strconv.Atoi(matches[1])
+			amount, err := parseAmount(matches[1])
 			if err != nil {
-				return fmt.Errorf("failed to parse uncalled bet amount %q in hand #%s: %w", matches[1], (*ctx.currentHand).HandNumber, err)
+				return ctx.amountError("uncalled bet", matches[1], err)
 			}
 			player := extractDisplayName(matches[2])
+			playerID := extractPlayerID(matches[2])
 			(*ctx.currentHand).Actions = append((*ctx.currentHand).Actions, Action{
 				Player:     player,
+				PlayerID:   playerID,
 				ActionType: ActionUncalled,
-				Amount:     float64(amount),
+				Amount:     amount,
 				Street:     *ctx.currentStreet,
 			})
 			return nil
 		},
 	},
+	// Dead small blind: nobody posts a small blind, which the blinds posted already show
+	{
+		pattern: reNoSmallBlind,
+		handle: func(matches []string, ctx *parseContext) error {
+			return nil
+		},
+	},
 }
 
 // ParseHands parses LogEntry slice into Hand slice
 // Returns ErrSpectatorLog if no hero cards are found in any hand (spectator log)
 func ParseHands(entries []LogEntry, opts ConvertOptions) ([]Hand, int, []SkippedHandInfo, error) {
-	var hands []Hand
-	var currentHand *Hand
-	var currentStreet Street
-	skippedHands := 0
-	var skippedHandsInfo []SkippedHandInfo
-	handStartIndex := -1 // Track the start index of current hand
-
-	// Helper function to extract raw input entries for a hand
-	extractRawInput := func(startIdx, endIdx int) []string {
-		if startIdx < 0 || startIdx >= len(entries) {
-			return nil
-		}
-		if endIdx > len(entries) {
-			endIdx = len(entries)
+	parser := newHandParser(opts)
+	for _, entry := range entries {
+		if err := parser.observe(entry); err != nil {
+			return nil, 0, nil, err
 		}
-		rawInput := make([]string, 0, endIdx-startIdx)
-		for j := startIdx; j < endIdx; j++ {
-			rawInput = append(rawInput, entries[j].Entry)
+	}
+	return parser.finish()
+}
+
+// handParser parses a chronological stream of log entries one hand at a time.
+// Only the entries of the hand being parsed are kept, so arbitrarily long logs can be streamed through it.
+type handParser struct {
+	opts             ConvertOptions
+	hands            []Hand
+	skippedHands     int
+	skippedHandsInfo []SkippedHandInfo
+
+	currentHand   *Hand
+	currentStreet Street
+	handEntries   []LogEntry           // 現在のハンドの生エントリ（スキップ時の RawInput 用）
+	skipping      *SkippedHandInfo     // スキップ中のハンド（終了マーカーまで RawInput を集める）
+	diagnostics   []Diagnostic         // ハンド内で認識できなかったエントリ
+	handWarnings  []Warning            // 現在のハンドの警告（ハンドが変換されたら warnings に移す）
+	warnings      []Warning            // 変換したハンドの警告
+	checks        map[string]handCheck // 変換したハンドの検証結果（ハンドID別）
+
+	// Blind level timeline: the first hand is level 1 and every group of blind/ante
+	// changes made after play started moves the following hand to the next level
+	level        int
+	levelChanged bool
+
+	ctx *parseContext
+}
+
+func newHandParser(opts ConvertOptions) *handParser {
+	p := &handParser{opts: opts}
+	p.ctx = &parseContext{
+		currentStreet: &p.currentStreet,
+		currentHand:   &p.currentHand,
+		opts:          opts,
+		skipHand:      p.skipCurrentHand,
+		warn: func(w Warning) {
+			p.handWarnings = append(p.handWarnings, w)
+		},
+	}
+	return p
+}
+
+// skipCurrentHand drops the hand being parsed and starts collecting its raw input
+func (p *handParser) skipCurrentHand(reason SkipReason, detail string, playerCount int) {
+	p.skippedHands++
+	p.skipping = &SkippedHandInfo{
+		HandID:      p.currentHand.HandID,
+		HandNumber:  p.currentHand.HandNumber,
+		Reason:      reason,
+		Detail:      detail,
+		PlayerCount: playerCount,
+	}
+	p.currentHand = nil
+}
+
+// finishSkip records the hand being skipped with the raw input collected so far
+func (p *handParser) finishSkip() {
+	p.skipping.RawInput = rawInput(p.handEntries)
+	p.skippedHandsInfo = append(p.skippedHandsInfo, *p.skipping)
+	p.skipping = nil
+}
+
+// rawInput returns the text of the given entries
+func rawInput(entries []LogEntry) []string {
+	raw := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		raw = append(raw, entry.Entry)
+	}
+	return raw
+}
+
+// observe processes the next log entry in chronological order
+func (p *handParser) observe(entry LogEntry) error {
+	text := entry.Entry
+
+	// Blind/ante change — logged between hands and takes effect from the next hand.
+	// Changes made before the first hand are the starting structure, not a new level.
+	if matches := reBlindChange.FindStringSubmatch(text); matches != nil {
+		if p.level > 0 && matches[2] != matches[3] {
+			p.levelChanged = true
 		}
-		return rawInput
-	}
-
-	ctx := &parseContext{
-		currentStreet:   &currentStreet,
-		currentHand:     &currentHand,
-		handStartIndex:  &handStartIndex,
-		entries:         entries,
-		opts:            opts,
-		skippedHands:    &skippedHands,
-		skippedInfo:     &skippedHandsInfo,
-		extractRawInput: extractRawInput,
-	}
-
-	for i := 0; i < len(entries); i++ {
-		entry := entries[i].Entry
-
-		// Starting hand — handled inline because it creates a new hand
-		if matches := reStartingHand.FindStringSubmatch(entry); matches != nil {
-			if currentHand != nil {
-				// Previous hand was not properly closed
-				skippedHands++
-				skippedHandsInfo = append(skippedHandsInfo, SkippedHandInfo{
-					HandID:     currentHand.HandID,
-					HandNumber: currentHand.HandNumber,
-					Reason:     SkipReasonIncomplete,
-					Detail:     fmt.Sprintf("Hand #%s was not properly closed before hand #%s started", currentHand.HandNumber, matches[1]),
-					RawInput:   extractRawInput(handStartIndex, i),
-				})
-			}
-			handNum := matches[1]
-			handID := matches[2]
-			// If no ID, use hand number
-			if handID == "" {
-				handID = handNum
-			}
-			// Convert hand ID to numeric format for compatibility
-			handID = convertHandIDToNumeric(handID)
-			dealer := extractDisplayName(matches[3])
-
-			currentHand = &Hand{
-				HandNumber: handNum,
-				HandID:     handID,
-				Dealer:     dealer,
-				StartTime:  entries[i].At,
-			}
-			handStartIndex = i // Record the start index
-			currentStreet = StreetPreflop
-			continue
+		if p.currentHand != nil || p.skipping != nil {
+			p.handEntries = append(p.handEntries, entry)
 		}
+		return nil
+	}
 
-		// Ending hand — handled inline because it finalizes the hand
-		if matches := reEndingHand.FindStringSubmatch(entry); matches != nil {
-			if currentHand != nil {
-				hands = append(hands, *currentHand)
-				currentHand = nil
+	// Starting hand — handled inline because it creates a new hand
+	if matches := reStartingHand.FindStringSubmatch(text); matches != nil {
+		p.startHand(matches, entry)
+		return nil
+	}
+
+	if p.currentHand == nil && p.skipping == nil {
+		return nil
+	}
+	p.handEntries = append(p.handEntries, entry)
+
+	// Ending hand — handled inline because it finalizes the hand
+	if matches := reEndingHand.FindStringSubmatch(text); matches != nil {
+		if p.skipping != nil {
+			// Keep collecting until the skipped hand's own ending marker
+			if matches[1] == p.skipping.HandNumber {
+				p.finishSkip()
+				p.handEntries = nil
 			}
-			handStartIndex = -1 // Reset start index
-			continue
+			return nil
 		}
+		p.endHand()
+		return nil
+	}
 
-		// Skip if no current hand
-		if currentHand == nil {
-			continue
-		}
+	// Skip the rest of a dropped hand
+	if p.skipping != nil {
+		return nil
+	}
 
-		// Dispatch via handler table
-		ctx.entryIndex = i
-		for _, h := range logHandlers {
-			if matches := h.pattern.FindStringSubmatch(entry); matches != nil {
-				if err := h.handle(matches, ctx); err != nil {
-					return nil, 0, nil, err
-				}
-				break
-			}
+	// Dispatch via handler table
+	p.ctx.entry = entry
+	for _, h := range logHandlers {
+		if matches := h.pattern.FindStringSubmatch(text); matches != nil {
+			return h.handle(matches, p.ctx)
 		}
 	}
+	// Session events are logged inside hands too; the ledger handles them
+	if isSessionEntry(text) {
+		return nil
+	}
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		HandNumber: p.currentHand.HandNumber,
+		Order:      entry.Order,
+		Entry:      text,
+	})
+	return nil
+}
+
+// startHand opens a new hand from a "starting hand" entry
+func (p *handParser) startHand(matches []string, entry LogEntry) {
+	if p.skipping != nil {
+		// The skipped hand never logged its ending marker
+		p.finishSkip()
+	}
+	if p.currentHand != nil {
+		// Previous hand was not properly closed
+		p.skippedHands++
+		p.skippedHandsInfo = append(p.skippedHandsInfo, SkippedHandInfo{
+			HandID:     p.currentHand.HandID,
+			HandNumber: p.currentHand.HandNumber,
+			Reason:     SkipReasonIncomplete,
+			Detail:     fmt.Sprintf("Hand #%s was not properly closed before hand #%s started", p.currentHand.HandNumber, matches[1]),
+			RawInput:   rawInput(p.handEntries),
+		})
+	}
+	p.handEntries = []LogEntry{entry}
+	p.handWarnings = nil
+
+	if p.level == 0 || p.levelChanged {
+		p.level++
+		p.levelChanged = false
+	}
+
+	handNum := matches[1]
+	handID := matches[2]
+	// If no ID, use hand number
+	if handID == "" {
+		handID = handNum
+	}
+	// Convert hand ID to numeric format for compatibility
+	numericID := convertHandIDToNumeric(handID)
+	if w, ok := handIDHashedWarning(handID, numericID); ok {
+		p.handWarnings = append(p.handWarnings, w)
+	}
+	handID = numericID
+	dealer := extractDisplayName(matches[4])
+	dealerID := ""
+	if dealer != "" {
+		dealerID = extractPlayerID(matches[4])
+	}
+
+	p.currentHand = &Hand{
+		HandNumber: handNum,
+		HandID:     handID,
+		Dealer:     dealer,
+		DealerID:   dealerID,
+		StartTime:  entry.At,
+		Level:      p.level,
+	}
+	p.currentStreet = StreetPreflop
+
+	game, limit, ok := parsePokerNowGame(matches[3])
+	if !ok {
+		// Unsupported game (e.g., Hi/Lo variants): skip the whole hand
+		p.skipCurrentHand(SkipReasonUnsupportedGame, fmt.Sprintf("Hand #%s is %s, which is not supported", handNum, matches[3]), 0)
+		return
+	}
+	p.currentHand.Game = game
+	p.currentHand.Limit = limit
+}
+
+// endHand finalizes the current hand at its "ending hand" entry
+func (p *handParser) endHand() {
+	hand := p.currentHand
+	p.currentHand = nil
+	entries := p.handEntries
+	p.handEntries = nil
+
+	assignWinnerRuns(hand)
+	p.hands = append(p.hands, *hand)
+	p.checks = checkHand(p.checks, *hand, func() []string { return rawInput(entries) })
+	p.warnings = append(p.warnings, p.handWarnings...)
+	p.handWarnings = nil
+}
+
+// finish completes parsing once all entries have been observed
+// A hand still open at the end of the log is dropped, as it never finished.
+func (p *handParser) finish() ([]Hand, int, []SkippedHandInfo, error) {
+	if p.skipping != nil {
+		p.finishSkip()
+	}
 
 	// Check if this is a spectator log (no hero cards in any hand)
-	if len(hands) > 0 {
+	if len(p.hands) > 0 {
 		hasAnyHeroCards := false
-		for _, hand := range hands {
+		for _, hand := range p.hands {
 			if len(hand.HeroCards) > 0 {
 				hasAnyHeroCards = true
 				break
@@ -540,42 +822,155 @@ func ParseHands(entries []LogEntry, opts ConvertOptions) ([]Hand, int, []Skipped
 		}
 	}
 
-	return hands, skippedHands, skippedHandsInfo, nil
+	return p.hands, p.skippedHands, p.skippedHandsInfo, nil
+}
+
+// parsePokerNowGame maps the game name in a PokerNow "starting hand" entry to a game and bet limit
+// Example: "No Limit Texas Hold'em" -> PokerGameHoldem, BetLimitNoLimit
+// Example: "Pot Limit Omaha Hi" -> PokerGameOmaha, BetLimitPotLimit
+// Example: "Pot Limit Omaha Hi 5 Cards" -> PokerGameOmaha5, BetLimitPotLimit
+// Returns false for games that cannot be converted (e.g., Omaha Hi/Lo)
+func parsePokerNowGame(name string) (PokerGame, BetLimit, bool) {
+	name = strings.TrimSpace(name)
+	if name == "No Limit Texas Hold'em" {
+		return PokerGameHoldem, BetLimitNoLimit, true
+	}
+	if matches := reOmahaGame.FindStringSubmatch(name); matches != nil {
+		switch matches[1] {
+		case "5":
+			return PokerGameOmaha5, BetLimitPotLimit, true
+		case "6":
+			return PokerGameOmaha6, BetLimitPotLimit, true
+		default:
+			return PokerGameOmaha, BetLimitPotLimit, true
+		}
+	}
+	return PokerGameHoldem, BetLimitNoLimit, false
+}
+
+// omahaVariantForCards upgrades an Omaha game to the 5 or 6 card variant based on the number of hole cards
+// PokerNow does not always spell out the card count in the game name, so the dealt cards are authoritative
+func omahaVariantForCards(game PokerGame, cardCount int) PokerGame {
+	if game == PokerGameHoldem {
+		return game
+	}
+	switch cardCount {
+	case 5:
+		return PokerGameOmaha5
+	case 6:
+		return PokerGameOmaha6
+	default:
+		return game
+	}
+}
+
+// setBoardRunStreet records the cards of one street for the given run of the board
+// runWord is the ordinal from the PokerNow entry (e.g., "second" in "Flop (second run)").
+// A new run starts as a copy of the previous run, so cards dealt before the board
+// was split are shared; the given street overwrites the copy and clears later streets.
+func setBoardRunStreet(hand *Hand, runWord string, street Street, cards []string) {
+	index, ok := runIndexFromWord(runWord)
+	if !ok {
+		return
+	}
+
+	if index == 0 {
+		first := BoardRun{Flop: hand.Board.Flop, Turn: hand.Board.Turn, River: hand.Board.River}
+		first.setStreet(street, cards)
+		hand.Board.Flop, hand.Board.Turn, hand.Board.River = first.Flop, first.Turn, first.River
+		return
+	}
+
+	for len(hand.Board.Runs) < index {
+		runs := hand.Board.allRuns()
+		prev := runs[len(runs)-1]
+		hand.Board.Runs = append(hand.Board.Runs, BoardRun{
+			Flop:  append([]string{}, prev.Flop...),
+			Turn:  prev.Turn,
+			River: prev.River,
+		})
+	}
+	hand.Board.Runs[index-1].setStreet(street, cards)
 }
 
-// findEndingHandIndex finds the index of the ending hand marker for a given hand number
-// Returns the index after the ending hand marker, or len(entries) if not found
-func findEndingHandIndex(entries []LogEntry, startIdx int, handNumber string) int {
-	endPattern := fmt.Sprintf("-- ending hand #%s --", handNumber)
-	for j := startIdx; j < len(entries); j++ {
-		if entries[j].Entry == endPattern {
-			return j + 1 // Include the ending hand marker
+// setStreet overwrites one street of the run and clears the streets dealt after it
+func (r *BoardRun) setStreet(street Street, cards []string) {
+	switch street {
+	case StreetFlop:
+		r.Flop = cards
+		r.Turn = ""
+		r.River = ""
+	case StreetTurn:
+		r.Turn = cards[0]
+		r.River = ""
+	case StreetRiver:
+		r.River = cards[0]
+	}
+}
+
+// assignWinnerRuns attributes each collection of a hand that was run more than once to its run
+// PokerNow awards each run's share of the pot in order, so the collections are walked in order
+// and the run index advances once the running total reaches that run's share of the pot.
+// Odd chips can make the shares differ slightly, so the comparison allows one chip (or cent) of slack.
+func assignWinnerRuns(hand *Hand) {
+	runCount := len(hand.Board.Runs) + 1
+	if runCount == 1 {
+		return
+	}
+
+	total := 0.0
+	slack := 1.0
+	for _, w := range hand.Winners {
+		total += w.Amount
+		if math.Trunc(w.Amount) != w.Amount {
+			slack = 0.01
+		}
+	}
+	share := total / float64(runCount)
+
+	run := 0
+	paid := 0.0
+	for i := range hand.Winners {
+		if hand.Winners[i].Amount <= 0 {
+			continue
+		}
+		hand.Winners[i].Run = run
+		paid = roundAmount(paid + hand.Winners[i].Amount)
+		if run < runCount-1 && paid >= share*float64(run+1)-slack {
+			run++
 		}
 	}
-	return len(entries) // Not found, return end of entries
 }
 
 // parsePlayerStacks parses player stacks string
+// The seats are returned as logged; see normalizePlayerSeats.
 // Example: "#5 "ramune @ 3rSQmMhWok" (66998) | #9 "whywaita @ DtjzvbAuKs" (383002)"
-func parsePlayerStacks(stacksStr string) []Player {
+func parsePlayerStacks(stacksStr string) ([]Player, error) {
 	var players []Player
 	parts := strings.Split(stacksStr, "|")
 
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
 		if matches := rePlayerStack.FindStringSubmatch(part); matches != nil {
-			seatNum, _ := strconv.Atoi(matches[1])
+			seatNum, err := strconv.Atoi(matches[1])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse seat number %q: %w", matches[1], err)
+			}
 			fullName := matches[2]
-			stack, _ := strconv.Atoi(matches[3])
+			stack, err := parseAmount(matches[3])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse stack amount %q of %q: %w", matches[3], fullName, err)
+			}
 
 			players = append(players, Player{
 				SeatNumber:  seatNum,
 				Name:        fullName,
 				DisplayName: extractDisplayName(fullName),
-				Stack:       float64(stack),
+				ID:          extractPlayerID(fullName),
+				Stack:       stack,
 			})
 		}
 	}
 
-	return normalizePlayerSeats(players)
+	return players, nil
 }
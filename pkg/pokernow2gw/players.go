@@ -0,0 +1,234 @@
+package pokernow2gw
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PlayerNaming selects how players identified by a PokerNow player ID are named in the output
+// The output names are unique: when two player IDs would get the same name, the later one
+// is written as "name_2", "name_3", ...
+type PlayerNaming string
+
+const (
+	// PlayerNamingFirst names each player after the first display name used with their ID (default)
+	PlayerNamingFirst PlayerNaming = "first"
+	// PlayerNamingLast names each player after the last display name used with their ID
+	PlayerNamingLast PlayerNaming = "last"
+	// PlayerNamingID names each player after their PokerNow player ID
+	PlayerNamingID PlayerNaming = "id"
+)
+
+// PlayerNamings returns the supported player naming modes
+func PlayerNamings() []PlayerNaming {
+	return []PlayerNaming{PlayerNamingFirst, PlayerNamingLast, PlayerNamingID}
+}
+
+// joinPlayerNamings returns the namings as a comma separated list
+func joinPlayerNamings(namings []PlayerNaming) string {
+	names := make([]string, 0, len(namings))
+	for _, naming := range namings {
+		names = append(names, string(naming))
+	}
+	return strings.Join(names, ", ")
+}
+
+// key returns the stable identity of a player: the player ID, or the display name for inputs without IDs
+func (p Player) key() string {
+	if p.ID != "" {
+		return p.ID
+	}
+	return p.DisplayName
+}
+
+// playerKey returns the Player.key of the player who acted
+func (a Action) playerKey() string {
+	if a.PlayerID != "" {
+		return a.PlayerID
+	}
+	return a.Player
+}
+
+// playerKey returns the Player.key of the winner
+func (w Winner) playerKey() string {
+	if w.PlayerID != "" {
+		return w.PlayerID
+	}
+	return w.Player
+}
+
+// dealerKey returns the Player.key of the button
+func (h Hand) dealerKey() string {
+	if h.DealerID != "" {
+		return h.DealerID
+	}
+	return h.Dealer
+}
+
+// playerKeyByName returns the Player.key of the player seated in the hand under the display name
+func playerKeyByName(hand Hand, name string) string {
+	for _, player := range hand.Players {
+		if player.DisplayName == name {
+			return player.key()
+		}
+	}
+	return name
+}
+
+// ReadPlayerAliases reads a player alias file
+// The file is a JSON object mapping PokerNow player IDs or display names to canonical names:
+//
+//	{"DtjzvbAuKs": "whywaita", "why": "whywaita"}
+func ReadPlayerAliases(r io.Reader) (map[string]string, error) {
+	var aliases map[string]string
+	if err := json.NewDecoder(r).Decode(&aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse player aliases: %w", err)
+	}
+	for from, to := range aliases {
+		if to == "" {
+			return nil, fmt.Errorf("player alias for %q is empty", from)
+		}
+	}
+	return aliases, nil
+}
+
+// playerAlias returns the alias of a player, looked up by player ID first and then by each display name the player used
+func playerAlias(aliases map[string]string, id string, displayNames []string) (string, bool) {
+	if alias, ok := aliases[id]; ok && id != "" {
+		return alias, true
+	}
+	for _, name := range displayNames {
+		if alias, ok := aliases[name]; ok {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+// assignPlayerNames rewrites the display names of players to their output names
+// Players found in aliases (by player ID or display name) get their canonical name. Other players with
+// an ID are named according to naming, so one player keeps one name for the whole session even after
+// renaming, and players sharing a nickname get distinct names. Players without an ID keep their display
+// names, which the output names never collide with. Actions, winners and the dealer are renamed along
+// with the seats. Aliasing two players seated in the same hand to one name is an error.
+func assignPlayerNames(hands []Hand, naming PlayerNaming, aliases map[string]string) error {
+	if naming == "" {
+		naming = PlayerNamingFirst
+	}
+	if !slices.Contains(PlayerNamings(), naming) {
+		return fmt.Errorf("unknown player naming %q (supported: %s)", naming, joinPlayerNamings(PlayerNamings()))
+	}
+
+	var ids []string // in order of first appearance
+	wanted := make(map[string]string)
+	displayNames := make(map[string][]string)
+	taken := make(map[string]bool)
+	aliased := make(map[string]string) // プレイヤーIDのない表示名 → 別名
+	for _, hand := range hands {
+		for _, player := range hand.Players {
+			if player.ID == "" {
+				if alias, ok := playerAlias(aliases, "", []string{player.DisplayName}); ok {
+					aliased[player.DisplayName] = alias
+					taken[alias] = true
+				} else {
+					taken[player.DisplayName] = true
+				}
+				continue
+			}
+			if !slices.Contains(displayNames[player.ID], player.DisplayName) {
+				displayNames[player.ID] = append(displayNames[player.ID], player.DisplayName)
+			}
+			if _, seen := wanted[player.ID]; !seen {
+				ids = append(ids, player.ID)
+			} else if naming != PlayerNamingLast {
+				continue
+			}
+			wanted[player.ID] = player.DisplayName
+			if naming == PlayerNamingID {
+				wanted[player.ID] = player.ID
+			}
+		}
+	}
+	if len(ids) == 0 && len(aliased) == 0 {
+		return nil
+	}
+
+	// Aliases are assigned first so that no other player is given a canonical name
+	names := make(map[string]string, len(ids))
+	for _, id := range ids {
+		if alias, ok := playerAlias(aliases, id, displayNames[id]); ok {
+			names[id] = alias
+			taken[alias] = true
+		}
+	}
+	for _, id := range ids {
+		if _, ok := names[id]; ok {
+			continue
+		}
+		name := wanted[id]
+		for n := 2; taken[name]; n++ {
+			name = wanted[id] + "_" + strconv.Itoa(n)
+		}
+		taken[name] = true
+		names[id] = name
+	}
+
+	outputName := func(id, name string) (string, bool) {
+		if id != "" {
+			outName, ok := names[id]
+			return outName, ok
+		}
+		outName, ok := aliased[name]
+		return outName, ok
+	}
+
+	for i := range hands {
+		hand := &hands[i]
+		seatedBy := make(map[string]string, len(hand.Players))
+		for j, player := range hand.Players {
+			if name, ok := outputName(player.ID, player.DisplayName); ok {
+				hand.Players[j].DisplayName = name
+			}
+			name := hand.Players[j].DisplayName
+			if other, dup := seatedBy[name]; dup {
+				return fmt.Errorf("players %s and %s are both named %q in hand #%s; check the player aliases", other, player.Name, name, hand.HandNumber)
+			}
+			seatedBy[name] = player.Name
+		}
+		for j, action := range hand.Actions {
+			if name, ok := outputName(action.PlayerID, action.Player); ok {
+				hand.Actions[j].Player = name
+			}
+		}
+		for j, winner := range hand.Winners {
+			if name, ok := outputName(winner.PlayerID, winner.Player); ok {
+				hand.Winners[j].Player = name
+			}
+		}
+		if name, ok := outputName(hand.DealerID, hand.Dealer); ok {
+			hand.Dealer = name
+		}
+	}
+	return nil
+}
+
+// applyLedgerAliases renames the ledger players and events found in aliases (by player ID or name)
+func applyLedgerAliases(ledger *Ledger, aliases map[string]string) {
+	if ledger == nil || len(aliases) == 0 {
+		return
+	}
+	for i, p := range ledger.Players {
+		if alias, ok := playerAlias(aliases, p.PlayerID, []string{p.PlayerName}); ok {
+			ledger.Players[i].PlayerName = alias
+		}
+	}
+	for i, e := range ledger.Events {
+		if alias, ok := playerAlias(aliases, e.PlayerID, []string{e.PlayerName}); ok {
+			ledger.Events[i].PlayerName = alias
+		}
+	}
+}
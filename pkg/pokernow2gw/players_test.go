@@ -0,0 +1,432 @@
+package pokernow2gw
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignPlayerNames(t *testing.T) {
+	// bob (B1) renames to robert in the second hand; another bob (B2) joins with the same nickname,
+	// deals the second hand and splits the pot with B1. Every reference is renamed by player ID.
+	hands := func() []Hand {
+		return []Hand{
+			{
+				Dealer:   "bob",
+				DealerID: "B1",
+				Players: []Player{
+					{Name: "bob @ B1", DisplayName: "bob", ID: "B1"},
+					{Name: "carol @ C1", DisplayName: "carol", ID: "C1"},
+				},
+				Actions: []Action{
+					{Player: "bob", PlayerID: "B1", ActionType: ActionFold},
+					{Player: "carol", PlayerID: "C1", ActionType: ActionCollect, Amount: 10},
+				},
+				Winners: []Winner{{Player: "carol", PlayerID: "C1", Amount: 10}},
+			},
+			{
+				Dealer:   "bob",
+				DealerID: "B2",
+				Players: []Player{
+					{Name: "robert @ B1", DisplayName: "robert", ID: "B1"},
+					{Name: "bob @ B2", DisplayName: "bob", ID: "B2"},
+				},
+				Actions: []Action{
+					{Player: "bob", PlayerID: "B2", ActionType: ActionCheck},
+					{Player: "robert", PlayerID: "B1", ActionType: ActionCheck},
+					{Player: "robert", PlayerID: "B1", ActionType: ActionCollect, Amount: 5},
+					{Player: "bob", PlayerID: "B2", ActionType: ActionCollect, Amount: 5},
+				},
+				Winners: []Winner{{Player: "robert", PlayerID: "B1", Amount: 5}, {Player: "bob", PlayerID: "B2", Amount: 5}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		naming  PlayerNaming
+		want    [][]string // display names per hand
+		dealers []string
+		actors  [][]string
+		winners [][]string
+	}{
+		{
+			name:    "first name by default",
+			want:    [][]string{{"bob", "carol"}, {"bob", "bob_2"}},
+			dealers: []string{"bob", "bob_2"},
+			actors:  [][]string{{"bob", "carol"}, {"bob_2", "bob", "bob", "bob_2"}},
+			winners: [][]string{{"carol"}, {"bob", "bob_2"}},
+		},
+		{
+			name:    "last name",
+			naming:  PlayerNamingLast,
+			want:    [][]string{{"robert", "carol"}, {"robert", "bob"}},
+			dealers: []string{"robert", "bob"},
+			actors:  [][]string{{"robert", "carol"}, {"bob", "robert", "robert", "bob"}},
+			winners: [][]string{{"carol"}, {"robert", "bob"}},
+		},
+		{
+			name:    "player ID",
+			naming:  PlayerNamingID,
+			want:    [][]string{{"B1", "C1"}, {"B1", "B2"}},
+			dealers: []string{"B1", "B2"},
+			actors:  [][]string{{"B1", "C1"}, {"B2", "B1", "B1", "B2"}},
+			winners: [][]string{{"C1"}, {"B1", "B2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hands()
+			if err := assignPlayerNames(got, tt.naming, nil); err != nil {
+				t.Fatalf("assignPlayerNames() failed: %v", err)
+			}
+
+			var names, actors, winners [][]string
+			var dealers []string
+			for _, hand := range got {
+				var seated, acted, won []string
+				for _, p := range hand.Players {
+					seated = append(seated, p.DisplayName)
+				}
+				for _, a := range hand.Actions {
+					acted = append(acted, a.Player)
+				}
+				for _, w := range hand.Winners {
+					won = append(won, w.Player)
+				}
+				names = append(names, seated)
+				dealers = append(dealers, hand.Dealer)
+				actors = append(actors, acted)
+				winners = append(winners, won)
+			}
+			if diff := cmp.Diff(tt.want, names); diff != "" {
+				t.Errorf("display names mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.dealers, dealers); diff != "" {
+				t.Errorf("dealers mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.actors, actors); diff != "" {
+				t.Errorf("actions mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.winners, winners); diff != "" {
+				t.Errorf("winners mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("names without an ID are kept and never reused", func(t *testing.T) {
+		got := []Hand{{Players: []Player{
+			{Name: "bob", DisplayName: "bob"},
+			{Name: "bob @ B1", DisplayName: "bob", ID: "B1"},
+		}}}
+		if err := assignPlayerNames(got, "", nil); err != nil {
+			t.Fatalf("assignPlayerNames() failed: %v", err)
+		}
+		if got[0].Players[0].DisplayName != "bob" || got[0].Players[1].DisplayName != "bob_2" {
+			t.Errorf("display names = %q, %q, want bob, bob_2", got[0].Players[0].DisplayName, got[0].Players[1].DisplayName)
+		}
+	})
+
+	t.Run("unknown naming", func(t *testing.T) {
+		if err := assignPlayerNames(hands(), "nickname", nil); err == nil {
+			t.Error("assignPlayerNames() should fail for an unknown naming")
+		}
+	})
+}
+
+func TestSameNicknameDifferentPlayers(t *testing.T) {
+	// Two players named "bob": the button (B1) and the small blind (B2)
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,11
+"""bob @ B2"" collected 60 from pot",2025-11-15T05:09:14.567Z,10
+"Uncalled bet of 40 returned to ""bob @ B2""",2025-11-15T05:09:14.567Z,9
+"""bob @ B1"" folds",2025-11-15T05:09:14.567Z,8
+"""alice @ A1"" folds",2025-11-15T05:09:14.567Z,7
+"""bob @ B2"" raises to 60",2025-11-15T05:09:14.567Z,6
+"""bob @ B1"" calls 20",2025-11-15T05:09:14.567Z,5
+"""alice @ A1"" posts a big blind of 20",2025-11-15T05:09:14.567Z,4
+"""bob @ B2"" posts a small blind of 10",2025-11-15T05:09:14.567Z,3
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,2
+"Player stacks: #1 ""bob @ B1"" (1000) | #2 ""bob @ B2"" (1000) | #3 ""alice @ A1"" (1000)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""bob @ B1"") --",2025-11-15T05:09:14.567Z,0`
+
+	result, err := ParseCSV(strings.NewReader(csv), ConvertOptions{
+		HeroName:     "alice",
+		SiteName:     "PokerStars",
+		TimeLocation: time.UTC,
+		GameType:     GameTypeCash,
+	})
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+
+	assertInOrder(t, string(result.HH), []string{
+		"Seat 1: bob ($1000 in chips)",
+		"Seat 2: bob_2 ($1000 in chips)",
+		"bob_2: posts small blind $10",
+		"bob: calls $20",
+		// bob_2 has the small blind (10) in front, bob's call is not counted for them
+		"bob_2: raises $40 to $60",
+		"Uncalled bet ($40) returned to bob_2",
+		"bob_2 collected $60 from pot",
+		"Seat 1: bob (button) folded before Flop",
+		"Seat 2: bob_2 (small blind) collected ($60)",
+		"Seat 3: alice (big blind) folded before Flop",
+	})
+}
+
+func TestSameNicknameAsTheHero(t *testing.T) {
+	// Two players named "bob": B1 plays the first hand, which the hero is not dealt in, and B2 is the hero
+	csv := `entry,at,order
+"-- ending hand #2 --",2025-11-15T05:10:14.567Z,22
+"""bob @ B2"" collected 60 from pot",2025-11-15T05:10:14.567Z,21
+"Uncalled bet of 40 returned to ""bob @ B2""",2025-11-15T05:10:14.567Z,20
+"""bob @ B1"" folds",2025-11-15T05:10:14.567Z,19
+"""alice @ A1"" folds",2025-11-15T05:10:14.567Z,18
+"""bob @ B2"" raises to 60",2025-11-15T05:10:14.567Z,17
+"""bob @ B1"" calls 20",2025-11-15T05:10:14.567Z,16
+"""alice @ A1"" posts a big blind of 20",2025-11-15T05:10:14.567Z,15
+"""bob @ B2"" posts a small blind of 10",2025-11-15T05:10:14.567Z,14
+"Your hand is A♥, K♥",2025-11-15T05:10:14.567Z,13
+"Player stacks: #1 ""bob @ B1"" (990) | #2 ""bob @ B2"" (1000) | #3 ""alice @ A1"" (1010)",2025-11-15T05:10:14.567Z,12
+"-- starting hand #2 (id: test124) (No Limit Texas Hold'em) (dealer: ""bob @ B1"") --",2025-11-15T05:10:14.567Z,11
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,9
+"""alice @ A1"" collected 20 from pot",2025-11-15T05:09:14.567Z,8
+"Uncalled bet of 10 returned to ""alice @ A1""",2025-11-15T05:09:14.567Z,7
+"""bob @ B1"" folds",2025-11-15T05:09:14.567Z,6
+"""alice @ A1"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""bob @ B1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Player stacks: #1 ""bob @ B1"" (1000) | #2 ""alice @ A1"" (1000)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""bob @ B1"") --",2025-11-15T05:09:14.567Z,1`
+
+	for _, anonymize := range []Anonymization{"", AnonymizeSequential} {
+		t.Run("anonymize "+string(anonymize), func(t *testing.T) {
+			result, err := ParseCSV(strings.NewReader(csv), ConvertOptions{
+				HeroName:     "bob",
+				PlayerNaming: PlayerNamingFirst,
+				Anonymize:    anonymize,
+				SiteName:     "PokerStars",
+				TimeLocation: time.UTC,
+				GameType:     GameTypeCash,
+			})
+			if err != nil {
+				t.Fatalf("ParseCSV() failed: %v", err)
+			}
+			// B2 is renamed bob_2, and the hero name follows them
+			if result.HeroName != "bob_2" {
+				t.Errorf("HeroName = %q, want %q", result.HeroName, "bob_2")
+			}
+			hh := string(result.HH)
+			assertInOrder(t, hh, []string{
+				"Dealt to bob_2 [Ah Kh]",
+				"bob_2: raises $40 to $60",
+				"bob_2 collected $60 from pot",
+			})
+			if anonymize != "" && strings.Contains(hh, "bob:") {
+				t.Errorf("B1 should be anonymized\nGot:\n%s", hh)
+			}
+		})
+	}
+}
+
+func TestRenamedPlayerKeepsOneName(t *testing.T) {
+	// B1 plays the first hand as "bob" and the second as "robert"
+	csv := `entry,at,order
+"-- ending hand #2 --",2025-11-15T05:10:14.567Z,18
+"""alice @ A1"" collected 20 from pot",2025-11-15T05:10:14.567Z,17
+"Uncalled bet of 10 returned to ""alice @ A1""",2025-11-15T05:10:14.567Z,16
+"""robert @ B1"" folds",2025-11-15T05:10:14.567Z,15
+"""alice @ A1"" posts a big blind of 20",2025-11-15T05:10:14.567Z,14
+"""robert @ B1"" posts a small blind of 10",2025-11-15T05:10:14.567Z,13
+"Your hand is Q♠, Q♥",2025-11-15T05:10:14.567Z,12
+"Player stacks: #1 ""robert @ B1"" (1010) | #2 ""alice @ A1"" (990)",2025-11-15T05:10:14.567Z,11
+"-- starting hand #2 (id: test124) (No Limit Texas Hold'em) (dealer: ""robert @ B1"") --",2025-11-15T05:10:14.567Z,10
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,9
+"""bob @ B1"" collected 20 from pot",2025-11-15T05:09:14.567Z,8
+"Uncalled bet of 10 returned to ""bob @ B1""",2025-11-15T05:09:14.567Z,7
+"""alice @ A1"" folds",2025-11-15T05:09:14.567Z,6
+"""bob @ B1"" posts a big blind of 20",2025-11-15T05:09:14.567Z,5
+"""alice @ A1"" posts a small blind of 10",2025-11-15T05:09:14.567Z,4
+"Your hand is A♥, K♥",2025-11-15T05:09:14.567Z,3
+"Player stacks: #1 ""bob @ B1"" (1000) | #2 ""alice @ A1"" (1000)",2025-11-15T05:09:14.567Z,2
+"-- starting hand #1 (id: test123) (No Limit Texas Hold'em) (dealer: ""alice @ A1"") --",2025-11-15T05:09:14.567Z,1`
+
+	tests := []struct {
+		name     string
+		naming   PlayerNaming
+		aliases  map[string]string
+		heroName string
+		wantName string
+	}{
+		{name: "first name", naming: PlayerNamingFirst, heroName: "bob", wantName: "bob"},
+		{name: "first name with the hero given by the later name", naming: PlayerNamingFirst, heroName: "robert", wantName: "bob"},
+		{name: "last name", naming: PlayerNamingLast, heroName: "robert", wantName: "robert"},
+		{name: "alias by player ID", aliases: map[string]string{"B1": "Bobby"}, heroName: "robert", wantName: "Bobby"},
+		{name: "alias by an earlier name", aliases: map[string]string{"bob": "Bobby"}, heroName: "Bobby", wantName: "Bobby"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseCSV(strings.NewReader(csv), ConvertOptions{
+				HeroName:      tt.heroName,
+				PlayerNaming:  tt.naming,
+				PlayerAliases: tt.aliases,
+				SiteName:      "PokerStars",
+				TimeLocation:  time.UTC,
+				GameType:      GameTypeCash,
+			})
+			if err != nil {
+				t.Fatalf("ParseCSV() failed: %v", err)
+			}
+			if result.HeroName != tt.wantName {
+				t.Errorf("HeroName = %q, want %q", result.HeroName, tt.wantName)
+			}
+
+			hh := string(result.HH)
+			for _, other := range []string{"bob", "robert", "Bobby"} {
+				if other != tt.wantName && strings.Contains(hh, other) {
+					t.Errorf("output should name B1 %q in every hand, but %q appears\nGot:\n%s", tt.wantName, other, hh)
+				}
+			}
+			assertInOrder(t, hh, []string{
+				"Dealt to " + tt.wantName + " [Ah Kh]",
+				tt.wantName + " collected $20 from pot",
+				"Dealt to " + tt.wantName + " [Qs Qh]",
+				tt.wantName + ": folds",
+			})
+		})
+	}
+}
+
+func TestAssignPlayerNames_Aliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		hands   []Hand
+		aliases map[string]string
+		want    []string // display names of the first hand
+		actors  []string
+		wantErr bool
+	}{
+		{
+			name: "by player ID and by display name",
+			hands: []Hand{{
+				Players: []Player{
+					{Name: "why @ W1", DisplayName: "why", ID: "W1"},
+					{Name: "ramu @ R1", DisplayName: "ramu", ID: "R1"},
+				},
+				Actions: []Action{
+					{Player: "why", PlayerID: "W1", ActionType: ActionFold},
+					{Player: "ramu", PlayerID: "R1", ActionType: ActionCheck},
+				},
+			}},
+			aliases: map[string]string{"W1": "whywaita", "ramu": "ramune"},
+			want:    []string{"whywaita", "ramune"},
+			actors:  []string{"whywaita", "ramune"},
+		},
+		{
+			name: "canonical names are not given to other players",
+			hands: []Hand{{
+				Players: []Player{
+					{Name: "whywaita @ X1", DisplayName: "whywaita", ID: "X1"},
+					{Name: "why @ W1", DisplayName: "why", ID: "W1"},
+				},
+				Actions: []Action{
+					{Player: "whywaita", PlayerID: "X1", ActionType: ActionFold},
+					{Player: "why", PlayerID: "W1", ActionType: ActionCheck},
+				},
+			}},
+			aliases: map[string]string{"W1": "whywaita"},
+			want:    []string{"whywaita_2", "whywaita"},
+			actors:  []string{"whywaita_2", "whywaita"},
+		},
+		{
+			name: "players without an ID",
+			hands: []Hand{{
+				Players: []Player{
+					{Name: "why", DisplayName: "why"},
+					{Name: "ramune", DisplayName: "ramune"},
+				},
+				Actions: []Action{
+					{Player: "why", ActionType: ActionFold},
+					{Player: "ramune", ActionType: ActionCheck},
+				},
+			}},
+			aliases: map[string]string{"why": "whywaita"},
+			want:    []string{"whywaita", "ramune"},
+			actors:  []string{"whywaita", "ramune"},
+		},
+		{
+			name: "two seated players aliased to one name",
+			hands: []Hand{{
+				HandNumber: "3",
+				Players: []Player{
+					{Name: "why @ W1", DisplayName: "why", ID: "W1"},
+					{Name: "waita @ W2", DisplayName: "waita", ID: "W2"},
+				},
+			}},
+			aliases: map[string]string{"W1": "whywaita", "W2": "whywaita"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := assignPlayerNames(tt.hands, "", tt.aliases)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("assignPlayerNames() should fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("assignPlayerNames() failed: %v", err)
+			}
+
+			var names, actors []string
+			for _, p := range tt.hands[0].Players {
+				names = append(names, p.DisplayName)
+			}
+			for _, a := range tt.hands[0].Actions {
+				actors = append(actors, a.Player)
+			}
+			if diff := cmp.Diff(tt.want, names); diff != "" {
+				t.Errorf("display names mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.actors, actors); diff != "" {
+				t.Errorf("actions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadPlayerAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "IDs and names",
+			input: `{"DtjzvbAuKs": "whywaita", "why": "whywaita"}`,
+			want:  map[string]string{"DtjzvbAuKs": "whywaita", "why": "whywaita"},
+		},
+		{name: "not an object", input: `["whywaita"]`, wantErr: true},
+		{name: "empty alias", input: `{"why": ""}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadPlayerAliases(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadPlayerAliases() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ReadPlayerAliases() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
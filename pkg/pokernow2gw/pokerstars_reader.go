@@ -0,0 +1,401 @@
+package pokernow2gw
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pokerStarsTimeLayout is the timestamp layout in PokerStars hand headers
+const pokerStarsTimeLayout = "2006/01/02 15:04:05"
+
+var (
+	// Example: PokerStars Hand #123:  Tournament #123, $0+$0 Hold'em No Limit - Level IV (200/400) - 2025/11/15 03:00:08
+	rePSTournamentHeader = regexp.MustCompile(`^(.+?) Hand #([^:\s]+):\s+Tournament #([^,\s]+), (?:.*? )?((?:\d Card )?(?:Hold'em|Omaha) (?:No|Pot) Limit) - Level ([IVXLCDM]+) \((\d+(?:\.\d+)?)/(\d+(?:\.\d+)?)\) - (\d{4}/\d{2}/\d{2} \d{1,2}:\d{2}:\d{2})`)
+	// Example: PokerStars Hand #123: Hold'em No Limit ($1/$2 USD) - 2025/11/15 05:09:14 UTC
+	rePSCashHeader    = regexp.MustCompile(`^(.+?) Hand #([^:\s]+): ((?:\d Card )?(?:Hold'em|Omaha) (?:No|Pot) Limit) \((\$?)(\d+(?:\.\d+)?)/\$?(\d+(?:\.\d+)?)(?: USD)?\) - (\d{4}/\d{2}/\d{2} \d{1,2}:\d{2}:\d{2}) \S+`)
+	rePSTable         = regexp.MustCompile(`^Table '(.*)' \d+-max Seat #(\d+) is the button$`)
+	rePSSeat          = regexp.MustCompile(`^Seat (\d+): (.+) \(\$?(\d+(?:\.\d+)?) in chips\)`)
+	rePSDealt         = regexp.MustCompile(`^Dealt to (.+) \[([^\]]+)\]$`)
+	rePSStreet        = regexp.MustCompile(`^\*\*\* (?:([A-Z]+) )?(FLOP|TURN|RIVER) \*\*\* \[([^\]]*)\](?: \[([^\]]*)\])?$`)
+	rePSShowDown      = regexp.MustCompile(`^\*\*\* (?:([A-Z]+) )?SHOW DOWN \*\*\*$`)
+	rePSPost          = regexp.MustCompile(`^(.+): posts (small blind|big blind|small & big blinds|straddle|an ante of) \$?(\d+(?:\.\d+)?)$`)
+	rePSAction        = regexp.MustCompile(`^(.+): (folds|checks|calls|bets|raises)(?: \$?(\d+(?:\.\d+)?))?(?: to \$?(\d+(?:\.\d+)?))?( and is all-in)?$`)
+	rePSUncalled      = regexp.MustCompile(`^Uncalled bet \(\$?(\d+(?:\.\d+)?)\) returned to (.+)$`)
+	rePSShows         = regexp.MustCompile(`^(.+): shows \[([^\]]+)\](?: \((.+)\))?$`)
+	rePSCollected     = regexp.MustCompile(`^(.+) collected \$?(\d+(?:\.\d+)?) from (?:main |side )?pot(?:-\d+)?$`)
+	rePSBoard         = regexp.MustCompile(`^(?:([A-Z]+) )?Board \[([^\]]+)\]$`)
+	rePSShowedAndLost = regexp.MustCompile(`^Seat \d+: (.+?)(?: \((?:button|small blind|big blind)\))? showed and lost$`)
+)
+
+// ReadPokerStars reads PokerStars-style hand history text (as written by the pokerstars formatter)
+// and converts it to the selected output format
+// Timestamps in the headers are read in opts.TimeLocation (UTC if nil).
+func ReadPokerStars(r io.Reader, opts ConvertOptions) (*ConvertResult, error) {
+	result, err := readPokerStars(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return convertReadResult(result, opts)
+}
+
+// readPokerStars reads PokerStars hand history text into hands
+// Hands are read one at a time; a new hand starts at each header line, and any text before
+// the first header is ignored. Hands that cannot be read are skipped with their raw lines.
+func readPokerStars(r io.Reader, opts ConvertOptions) (*ReadResult, error) {
+	loc := opts.TimeLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	result := &ReadResult{TimeLocation: loc}
+
+	var lines []string
+	flush := func() {
+		if len(lines) == 0 {
+			return
+		}
+		hand, siteName, reason, detail := parsePokerStarsHand(lines, loc, opts)
+		if reason != "" {
+			result.SkippedHands++
+			result.SkippedHandsInfo = append(result.SkippedHandsInfo, SkippedHandInfo{
+				HandID:      hand.HandID,
+				HandNumber:  hand.HandNumber,
+				Reason:      reason,
+				Detail:      detail,
+				PlayerCount: len(hand.Players),
+				RawInput:    lines,
+			})
+		} else {
+			if result.SiteName == "" {
+				result.SiteName = siteName
+			}
+			// Only the hero's cards are dealt face up
+			if result.HeroName == "" {
+				for _, line := range lines {
+					if m := rePSDealt.FindStringSubmatch(line); m != nil {
+						result.HeroName = m[1]
+						break
+					}
+				}
+			}
+			result.Hands = append(result.Hands, hand)
+			result.checks = checkHand(result.checks, hand, func() []string { return lines })
+		}
+		lines = nil
+	}
+
+	br := bufio.NewReader(r)
+	for first := true; ; first = false {
+		rawLine, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read PokerStars hand history: %w", err)
+		}
+		if err == io.EOF && rawLine == "" {
+			break
+		}
+
+		line := strings.TrimSpace(rawLine)
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if isPokerStarsHeader(line) {
+			flush()
+		}
+		if line != "" && (len(lines) > 0 || isPokerStarsHeader(line)) {
+			lines = append(lines, line)
+		}
+
+		if err == io.EOF {
+			break
+		}
+	}
+	flush()
+
+	if isSpectatorLog(result.Hands) {
+		return nil, ErrSpectatorLog
+	}
+	return result, nil
+}
+
+// isPokerStarsHeader reports whether the line is the first line of a PokerStars hand
+func isPokerStarsHeader(line string) bool {
+	return rePSTournamentHeader.MatchString(line) || rePSCashHeader.MatchString(line)
+}
+
+// sniffPokerStars accepts input whose first non-empty line is a PokerStars hand header
+func sniffPokerStars(prefix []byte, complete bool) bool {
+	text := strings.TrimPrefix(string(prefix), "\ufeff")
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return isPokerStarsHeader(line)
+		}
+	}
+	return false
+}
+
+// parsePokerStarsHand parses the lines of a single PokerStars hand
+// It returns the hand and the site name from its header, or a skip reason and detail if
+// the hand cannot be read or is excluded by opts.PlayerCountFilter.
+func parsePokerStarsHand(lines []string, loc *time.Location, opts ConvertOptions) (Hand, string, SkipReason, string) {
+	var hand Hand
+	var siteName, gameName, timestamp string
+	cash := false
+
+	if m := rePSTournamentHeader.FindStringSubmatch(lines[0]); m != nil {
+		siteName, hand.HandID, gameName, timestamp = m[1], m[2], m[4], m[8]
+		hand.Level = romanNumeralValue(m[5])
+		hand.SmallBlind, _ = parseAmount(m[6])
+		hand.BigBlind, _ = parseAmount(m[7])
+	} else if m := rePSCashHeader.FindStringSubmatch(lines[0]); m != nil {
+		siteName, hand.HandID, gameName, timestamp = m[1], m[2], m[3], m[7]
+		cash = true
+		hand.SmallBlind, _ = parseAmount(m[5])
+		hand.BigBlind, _ = parseAmount(m[6])
+		if m[4] == "" {
+			hand.Currency = "Chips"
+		}
+	}
+	hand.HandNumber = hand.HandID
+
+	game, limit, ok := parsePokerStarsGame(gameName)
+	if !ok {
+		return hand, siteName, SkipReasonUnsupportedGame, fmt.Sprintf("Hand #%s is %s, which is not supported", hand.HandID, gameName)
+	}
+	hand.Game, hand.Limit = game, limit
+
+	startTime, err := time.ParseInLocation(pokerStarsTimeLayout, timestamp, loc)
+	if err != nil {
+		return hand, siteName, SkipReasonIncomplete, fmt.Sprintf("Hand #%s has an invalid timestamp %q", hand.HandID, timestamp)
+	}
+	hand.StartTime = startTime
+
+	street := StreetPreflop
+	run := 0
+	dealerSeat := 0
+	seated := true
+	inSummary := false
+	// Chips each player has put in on the current street; calls are logged as the street total
+	playerBets := make(map[string]float64)
+	sbPosted, bbPosted := false, false
+	var shows []Winner
+	var collected []Winner
+
+	for _, line := range lines[1:] {
+		if inSummary {
+			// The summary repeats the hand, except for the full board (streets without betting have
+			// no section of their own) and players who showed without revealing their cards
+			if m := rePSBoard.FindStringSubmatch(line); m != nil {
+				setPokerStarsBoard(&hand, m[1], strings.Fields(m[2]))
+			} else if m := rePSShowedAndLost.FindStringSubmatch(line); m != nil {
+				hand.Actions = append(hand.Actions, Action{Player: m[1], ActionType: ActionShow, Street: StreetShowdown})
+			}
+			continue
+		}
+
+		if m := rePSTable.FindStringSubmatch(line); m != nil {
+			if name := m[1]; cash && name != "Poker Now" {
+				hand.TableName = name
+			}
+			dealerSeat, _ = strconv.Atoi(m[2])
+			continue
+		}
+		if m := rePSSeat.FindStringSubmatch(line); m != nil && seated {
+			seat, _ := strconv.Atoi(m[1])
+			stack, _ := parseAmount(m[3])
+			hand.Players = append(hand.Players, Player{SeatNumber: seat, Name: m[2], DisplayName: m[2], Stack: stack})
+			continue
+		}
+
+		switch {
+		case line == "*** HOLE CARDS ***":
+			seated = false
+			continue
+		case line == "*** SUMMARY ***":
+			inSummary = true
+			continue
+		}
+
+		if m := rePSStreet.FindStringSubmatch(line); m != nil {
+			seated = false
+			street = map[string]Street{"FLOP": StreetFlop, "TURN": StreetTurn, "RIVER": StreetRiver}[m[2]]
+			playerBets = make(map[string]float64)
+
+			// The first bracket holds the cards dealt before this street, the second the new card
+			setPokerStarsBoard(&hand, m[1], append(strings.Fields(m[3]), strings.Fields(m[4])...))
+			continue
+		}
+		if m := rePSShowDown.FindStringSubmatch(line); m != nil {
+			street = StreetShowdown
+			if m[1] != "" {
+				run, _ = runIndexFromWord(m[1])
+			}
+			continue
+		}
+
+		if m := rePSDealt.FindStringSubmatch(line); m != nil {
+			hand.HeroCards = strings.Fields(m[2])
+			continue
+		}
+
+		if m := rePSPost.FindStringSubmatch(line); m != nil {
+			player := m[1]
+			amount, _ := parseAmount(m[3])
+			switch m[2] {
+			case "small blind":
+				// Only the first small blind is the blind of the hand; later ones are missed blinds
+				actionType := ActionPostSB
+				if sbPosted {
+					actionType = ActionPostDeadSB
+				} else {
+					playerBets[player] = amount
+				}
+				sbPosted = true
+				hand.Actions = append(hand.Actions, Action{Player: player, ActionType: actionType, Amount: amount, Street: street})
+			case "big blind":
+				actionType := ActionPostBB
+				if bbPosted {
+					actionType = ActionPostDeadBB
+				}
+				bbPosted = true
+				playerBets[player] = amount
+				hand.Actions = append(hand.Actions, Action{Player: player, ActionType: actionType, Amount: amount, Street: street})
+			case "small & big blinds":
+				// The dead small blind and the live big blind are written on one line
+				hand.Actions = append(hand.Actions,
+					Action{Player: player, ActionType: ActionPostDeadSB, Amount: roundAmount(amount - hand.BigBlind), Street: street},
+					Action{Player: player, ActionType: ActionPostDeadBB, Amount: hand.BigBlind, Street: street},
+				)
+				playerBets[player] = hand.BigBlind
+			case "straddle":
+				playerBets[player] = amount
+				hand.Actions = append(hand.Actions, Action{Player: player, ActionType: ActionPostStraddle, Amount: amount, Street: street})
+			case "an ante of":
+				hand.Ante = amount
+				hand.Actions = append(hand.Actions, Action{Player: player, ActionType: ActionPostAnte, Amount: amount, Street: street})
+			}
+			continue
+		}
+
+		if m := rePSAction.FindStringSubmatch(line); m != nil {
+			action := Action{Player: m[1], Street: street, IsAllIn: m[5] != ""}
+			amount, _ := parseAmount(m[3])
+			switch m[2] {
+			case "folds":
+				action.ActionType = ActionFold
+			case "checks":
+				action.ActionType = ActionCheck
+			case "calls":
+				// Hand amounts follow PokerNow, which logs the street total rather than the chips added
+				action.ActionType = ActionCall
+				action.Amount = roundAmount(playerBets[action.Player] + amount)
+				playerBets[action.Player] = action.Amount
+			case "bets":
+				action.ActionType = ActionBet
+				action.Amount = amount
+				playerBets[action.Player] = amount
+			case "raises":
+				action.ActionType = ActionRaise
+				if m[4] != "" {
+					amount, _ = parseAmount(m[4])
+				}
+				playerBets[action.Player] = amount
+				action.Amount = amount
+			}
+			hand.Actions = append(hand.Actions, action)
+			continue
+		}
+
+		if m := rePSUncalled.FindStringSubmatch(line); m != nil {
+			amount, _ := parseAmount(m[1])
+			hand.Actions = append(hand.Actions, Action{Player: m[2], ActionType: ActionUncalled, Amount: amount, Street: street})
+			continue
+		}
+
+		if m := rePSShows.FindStringSubmatch(line); m != nil {
+			hand.Actions = append(hand.Actions, Action{Player: m[1], ActionType: ActionShow, Street: StreetShowdown})
+			shows = append(shows, Winner{Player: m[1], HandCards: strings.Fields(m[2]), HandName: m[3]})
+			continue
+		}
+
+		if m := rePSCollected.FindStringSubmatch(line); m != nil {
+			amount, _ := parseAmount(m[2])
+			collected = append(collected, Winner{Player: m[1], Amount: amount, Run: run})
+			continue
+		}
+	}
+
+	if !inSummary {
+		return hand, siteName, SkipReasonIncomplete, fmt.Sprintf("Hand #%s has no summary section", hand.HandID)
+	}
+	if len(hand.Players) > 10 {
+		return hand, siteName, SkipReasonTooManyPlayers,
+			fmt.Sprintf("Hand #%s has %d players, but GTO Wizard only supports up to 10 players", hand.HandID, len(hand.Players))
+	}
+	if !opts.PlayerCountFilter.isPlayerCountAllowed(len(hand.Players)) {
+		return hand, siteName, SkipReasonFilteredOut,
+			fmt.Sprintf("Hand #%s has %d players, which does not match the selected filter", hand.HandID, len(hand.Players))
+	}
+
+	for _, player := range hand.Players {
+		if player.SeatNumber == dealerSeat {
+			hand.Dealer = player.DisplayName
+		}
+	}
+
+	// Collections carry the shown cards of their player; players who showed and won nothing
+	// are kept as winners without an amount, as the CSV parser does
+	hand.Winners = collected
+	won := make(map[string]bool)
+	for i, winner := range hand.Winners {
+		won[winner.Player] = true
+		for _, shown := range shows {
+			if shown.Player == winner.Player {
+				hand.Winners[i].HandCards = shown.HandCards
+				hand.Winners[i].HandName = shown.HandName
+			}
+		}
+	}
+	for _, shown := range shows {
+		if !won[shown.Player] {
+			shown.HandName = ""
+			hand.Winners = append(hand.Winners, shown)
+		}
+	}
+
+	return hand, siteName, "", ""
+}
+
+// setPokerStarsBoard records the board cards of one run, as dealt so far
+// ordinal is the run label of the line ("FIRST", "SECOND", ...), or empty if the board was run once.
+func setPokerStarsBoard(hand *Hand, ordinal string, cards []string) {
+	runWord := "first"
+	if ordinal != "" {
+		runWord = strings.ToLower(ordinal)
+	}
+	if len(cards) >= 3 {
+		setBoardRunStreet(hand, runWord, StreetFlop, cards[:3])
+	}
+	if len(cards) >= 4 {
+		setBoardRunStreet(hand, runWord, StreetTurn, cards[3:4])
+	}
+	if len(cards) >= 5 {
+		setBoardRunStreet(hand, runWord, StreetRiver, cards[4:5])
+	}
+}
+
+// parsePokerStarsGame maps a PokerStars game label back to a game and bet limit
+// Example: "Hold'em No Limit" -> PokerGameHoldem, BetLimitNoLimit
+func parsePokerStarsGame(label string) (PokerGame, BetLimit, bool) {
+	for _, game := range []PokerGame{PokerGameHoldem, PokerGameOmaha, PokerGameOmaha5, PokerGameOmaha6} {
+		for _, limit := range []BetLimit{BetLimitNoLimit, BetLimitPotLimit} {
+			if gameLabel(Hand{Game: game, Limit: limit}) == label {
+				return game, limit, true
+			}
+		}
+	}
+	return PokerGameHoldem, BetLimitNoLimit, false
+}
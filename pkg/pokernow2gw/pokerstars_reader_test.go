@@ -0,0 +1,192 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// assertPokerStarsRoundTrip checks that PokerStars text written by the converter reads back
+// into hands that are written out as exactly the same text
+func assertPokerStarsRoundTrip(t *testing.T, hh string, opts ConvertOptions) {
+	t.Helper()
+
+	result, err := readPokerStars(strings.NewReader(hh), opts)
+	if err != nil {
+		t.Fatalf("readPokerStars() failed: %v", err)
+	}
+	if result.SkippedHands > 0 {
+		t.Errorf("readPokerStars() skipped %d hands: %+v", result.SkippedHands, result.SkippedHandsInfo)
+	}
+
+	// The tournament ID is not part of a hand, so keep the one in the original header
+	if m := rePSTournamentHeader.FindStringSubmatch(hh); m != nil && opts.TournamentID == "" {
+		opts.TournamentID = m[3]
+	}
+	if diff := cmp.Diff(hh, convertHandsToHH(result.Hands, opts, pokerStarsDialect)); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadPokerStars(t *testing.T) {
+	hh := `PokerStars Hand #42: Hold'em No Limit ($1/$2 USD) - 2025/11/15 05:09:14 UTC
+Table 'Friday game' 3-max Seat #3 is the button
+Seat 1: alice ($200 in chips)
+Seat 2: bob ($150 in chips)
+Seat 3: carol ($300 in chips)
+*** HOLE CARDS ***
+Dealt to carol [Ah Kh]
+alice: posts small blind $1
+bob: posts big blind $2
+carol: raises $4 to $6
+alice: folds
+bob: calls $4
+*** FLOP *** [2c 7d Ts]
+bob: checks
+carol: bets $8
+bob: raises $16 to $24
+carol: calls $16
+*** TURN *** [2c 7d Ts] [Kd]
+bob: bets $120 and is all-in
+carol: folds
+Uncalled bet ($120) returned to bob
+bob: doesn't show hand
+bob collected $61 from pot
+*** SUMMARY ***
+Total pot $61 | Rake $0
+Board [2c 7d Ts Kd]
+Seat 1: alice (small blind) folded before Flop (didn't bet)
+Seat 2: bob (big blind) collected ($61)
+Seat 3: carol (button) folded on the Turn
+`
+
+	result, err := readPokerStars(strings.NewReader(hh), ConvertOptions{TimeLocation: time.UTC})
+	if err != nil {
+		t.Fatalf("readPokerStars() failed: %v", err)
+	}
+	if len(result.Hands) != 1 || result.SiteName != "PokerStars" {
+		t.Fatalf("readPokerStars() = %d hands from %q, want 1 hand from PokerStars", len(result.Hands), result.SiteName)
+	}
+
+	want := Hand{
+		HandNumber: "42",
+		HandID:     "42",
+		Dealer:     "carol",
+		Players: []Player{
+			{SeatNumber: 1, Name: "alice", DisplayName: "alice", Stack: 200},
+			{SeatNumber: 2, Name: "bob", DisplayName: "bob", Stack: 150},
+			{SeatNumber: 3, Name: "carol", DisplayName: "carol", Stack: 300},
+		},
+		Actions: []Action{
+			{Player: "alice", ActionType: ActionPostSB, Amount: 1, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionPostBB, Amount: 2, Street: StreetPreflop},
+			{Player: "carol", ActionType: ActionRaise, Amount: 6, Street: StreetPreflop},
+			{Player: "alice", ActionType: ActionFold, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionCall, Amount: 6, Street: StreetPreflop},
+			{Player: "bob", ActionType: ActionCheck, Street: StreetFlop},
+			{Player: "carol", ActionType: ActionBet, Amount: 8, Street: StreetFlop},
+			{Player: "bob", ActionType: ActionRaise, Amount: 24, Street: StreetFlop},
+			{Player: "carol", ActionType: ActionCall, Amount: 24, Street: StreetFlop},
+			{Player: "bob", ActionType: ActionBet, Amount: 120, Street: StreetTurn, IsAllIn: true},
+			{Player: "carol", ActionType: ActionFold, Street: StreetTurn},
+			{Player: "bob", ActionType: ActionUncalled, Amount: 120, Street: StreetTurn},
+		},
+		Board:      Board{Flop: []string{"2c", "7d", "Ts"}, Turn: "Kd"},
+		StartTime:  time.Date(2025, 11, 15, 5, 9, 14, 0, time.UTC),
+		SmallBlind: 1,
+		BigBlind:   2,
+		Winners:    []Winner{{Player: "bob", Amount: 61}},
+		HeroCards:  []string{"Ah", "Kh"},
+		TableName:  "Friday game",
+	}
+	if diff := cmp.Diff(want, result.Hands[0]); diff != "" {
+		t.Errorf("hand mismatch (-want +got):\n%s", diff)
+	}
+
+	assertPokerStarsRoundTrip(t, strings.TrimSuffix(hh, "\n")+"\n", ConvertOptions{HeroName: "carol", SiteName: "PokerStars", TimeLocation: time.UTC, GameType: GameTypeCash})
+}
+
+func TestReadPokerStars_SkipsIncompleteHands(t *testing.T) {
+	hh := `Notes exported from a tracker
+PokerStars Hand #1:  Tournament #1, $0+$0 Hold'em No Limit - Level II (20/40) - 2025/11/15 05:09:14
+Table 'PokerNow 1' 2-max Seat #1 is the button
+Seat 1: alice (1000 in chips)
+Seat 2: bob (1000 in chips)
+*** HOLE CARDS ***
+Dealt to alice [Ah Kh]
+
+PokerStars Hand #2:  Tournament #1, $0+$0 Hold'em No Limit - Level II (20/40) - 2025/11/15 05:10:14
+Table 'PokerNow 1' 2-max Seat #2 is the button
+Seat 1: alice (1000 in chips)
+Seat 2: bob (1000 in chips)
+*** HOLE CARDS ***
+Dealt to alice [Qs Qd]
+bob: posts small blind 20
+alice: posts big blind 40
+bob: folds
+Uncalled bet (20) returned to alice
+alice collected 40 from pot
+alice: doesn't show hand
+*** SUMMARY ***
+Total pot 40 | Rake 0
+Seat 1: alice (big blind) collected (40)
+Seat 2: bob (button) (small blind) folded before Flop
+`
+
+	result, err := readPokerStars(strings.NewReader(hh), ConvertOptions{})
+	if err != nil {
+		t.Fatalf("readPokerStars() failed: %v", err)
+	}
+	if len(result.Hands) != 1 || result.Hands[0].HandID != "2" || result.Hands[0].Level != 2 {
+		t.Errorf("readPokerStars() hands = %+v, want only hand #2 at level 2", result.Hands)
+	}
+	if result.SkippedHands != 1 || result.SkippedHandsInfo[0].Reason != SkipReasonIncomplete || len(result.SkippedHandsInfo[0].RawInput) != 6 {
+		t.Errorf("readPokerStars() skipped = %+v, want hand #1 as incomplete with its 6 lines", result.SkippedHandsInfo)
+	}
+}
+
+func TestPokerStarsInput_ConvertToOHH(t *testing.T) {
+	inputPath := "../../sample/output/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.txt"
+	input, err := os.ReadFile(inputPath)
+	if os.IsNotExist(err) {
+		t.Skip("Sample output file not found, skipping PokerStars input test")
+	}
+	if err != nil {
+		t.Fatalf("Failed to read sample file: %v", err)
+	}
+
+	// The input format is detected from the hand header
+	opts := ConvertOptions{HeroName: "whywaita", TimeLocation: time.UTC, OutputFormat: OutputFormatOHHJSONL}
+	result, err := Parse(bytes.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(result.HH)), "\n")
+	if want := strings.Count(string(input), "PokerStars Hand #"); len(lines) != want {
+		t.Errorf("Parse() wrote %d OHH hands, want %d", len(lines), want)
+	}
+	var spec OHHSpecFormat
+	if err := json.Unmarshal([]byte(lines[0]), &spec); err != nil {
+		t.Fatalf("output is not OHH JSON Lines: %v", err)
+	}
+	if spec.OHH.HeroPlayerID == 0 || len(spec.OHH.Rounds) == 0 {
+		t.Errorf("first hand has no hero or no rounds: %s", lines[0])
+	}
+}
+
+func TestRomanNumeralValue(t *testing.T) {
+	for n := 1; n <= 40; n++ {
+		if got := romanNumeralValue(romanNumeral(n)); got != n {
+			t.Errorf("romanNumeralValue(%q) = %d, want %d", romanNumeral(n), got, n)
+		}
+	}
+	if got := romanNumeralValue("IIZ"); got != 0 {
+		t.Errorf("romanNumeralValue(\"IIZ\") = %d, want 0", got)
+	}
+}
@@ -0,0 +1,190 @@
+package pokernow2gw
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// pot is a main or side pot and the players who can win it
+type pot struct {
+	Amount   float64
+	Eligible []string // フォールドしていない、このポットに全額拠出したプレイヤー（Player.key）
+}
+
+// playerContributions returns the chips each player put into the pot, keyed by player (see Player.key)
+// "calls", "raises to" and blind postings are street totals, so the largest amount per street counts;
+// antes and missing small blinds are dead money added on top. Uncalled bets are given back.
+func playerContributions(hand Hand) map[string]float64 {
+	total := make(map[string]float64)
+	streetCommit := make(map[Street]map[string]float64)
+
+	for _, action := range hand.Actions {
+		switch action.ActionType {
+		case ActionPostAnte, ActionPostDeadSB:
+			total[action.playerKey()] += action.Amount
+		case ActionPostSB, ActionPostBB, ActionPostStraddle, ActionPostDeadBB, ActionCall, ActionBet, ActionRaise:
+			if streetCommit[action.Street] == nil {
+				streetCommit[action.Street] = make(map[string]float64)
+			}
+			if action.Amount > streetCommit[action.Street][action.playerKey()] {
+				streetCommit[action.Street][action.playerKey()] = action.Amount
+			}
+		case ActionUncalled:
+			total[action.playerKey()] -= action.Amount
+		}
+	}
+
+	for _, commits := range streetCommit {
+		for player, amount := range commits {
+			total[player] += amount
+		}
+	}
+	for player, amount := range total {
+		total[player] = roundAmount(amount)
+	}
+	return total
+}
+
+// buildPots splits the chips put into the pot into the main pot and side pots
+// A new side pot starts above every amount a player went all-in for. Folded players' chips stay
+// in the pots they reached but they are not eligible to win them. Pots with the same set of
+// eligible players are merged, so a hand without a (contested) all-in has a single pot.
+func buildPots(hand Hand) []pot {
+	contributions := playerContributions(hand)
+
+	folded := make(map[string]bool)
+	allIn := make(map[string]bool)
+	for _, action := range hand.Actions {
+		if action.ActionType == ActionFold {
+			folded[action.playerKey()] = true
+		}
+		if action.IsAllIn {
+			allIn[action.playerKey()] = true
+		}
+	}
+	for _, player := range hand.Players {
+		if player.Stack > 0 && contributions[player.key()] >= player.Stack {
+			allIn[player.key()] = true
+		}
+	}
+
+	// Contribution levels at which a pot is capped: every live all-in amount, plus the top
+	var levels []float64
+	top := 0.0
+	for player, amount := range contributions {
+		if amount > top {
+			top = amount
+		}
+		if allIn[player] && !folded[player] && amount > 0 {
+			levels = append(levels, amount)
+		}
+	}
+	levels = append(levels, top)
+	sort.Float64s(levels)
+
+	// Deterministic order of players for the eligible lists
+	var players []string
+	for _, player := range hand.Players {
+		players = append(players, player.key())
+	}
+	for player := range contributions {
+		if !slices.Contains(players, player) {
+			players = append(players, player)
+		}
+	}
+
+	var pots []pot
+	prev := 0.0
+	for _, level := range levels {
+		if level <= prev {
+			continue
+		}
+		amount := 0.0
+		var eligible []string
+		for _, player := range players {
+			c := contributions[player]
+			amount += math.Max(0, math.Min(c, level)-prev)
+			if c >= level && !folded[player] {
+				eligible = append(eligible, player)
+			}
+		}
+		amount = roundAmount(amount)
+		prev = level
+		if amount <= 0 {
+			continue
+		}
+		// Chips nobody left in the hand can win (e.g., folded over-bets) stay in the previous pot
+		if len(pots) > 0 && (len(eligible) == 0 || slices.Equal(eligible, pots[len(pots)-1].Eligible)) {
+			pots[len(pots)-1].Amount = roundAmount(pots[len(pots)-1].Amount + amount)
+			continue
+		}
+		pots = append(pots, pot{Amount: amount, Eligible: eligible})
+	}
+
+	return pots
+}
+
+// potTotal returns the sum of all pots
+func potTotal(pots []pot) float64 {
+	total := 0.0
+	for _, p := range pots {
+		total += p.Amount
+	}
+	return roundAmount(total)
+}
+
+// assignWinnerPots works out which pot each collection of a hand came from
+// It returns one pot index per winner (-1 for winners that collected nothing), or nil when the
+// collections cannot be matched to the pots unambiguously; callers then fall back to "from pot".
+// Each pot is filled by the eligible collections in log order until its amount is reached,
+// which also covers chopped pots.
+func assignWinnerPots(hand Hand, pots []pot) []int {
+	if len(pots) < 2 || len(hand.Board.Runs) > 0 {
+		return nil
+	}
+
+	assigned := make([]int, len(hand.Winners))
+	for i := range assigned {
+		assigned[i] = -1
+	}
+
+	// Pots are paid out from the last side pot to the main pot; try exact single matches first
+	remaining := make([]float64, len(pots))
+	for i, p := range pots {
+		remaining[i] = p.Amount
+	}
+	for i, w := range hand.Winners {
+		if w.Amount <= 0 {
+			continue
+		}
+		for j := len(pots) - 1; j >= 0; j-- {
+			if remaining[j] == pots[j].Amount && roundAmount(w.Amount) == pots[j].Amount && slices.Contains(pots[j].Eligible, w.playerKey()) {
+				assigned[i] = j
+				remaining[j] = 0
+				break
+			}
+		}
+	}
+	for i, w := range hand.Winners {
+		if w.Amount <= 0 || assigned[i] != -1 {
+			continue
+		}
+		for j := len(pots) - 1; j >= 0; j-- {
+			if remaining[j] > 0 && w.Amount <= remaining[j] && slices.Contains(pots[j].Eligible, w.playerKey()) {
+				assigned[i] = j
+				remaining[j] = roundAmount(remaining[j] - w.Amount)
+				break
+			}
+		}
+		if assigned[i] == -1 {
+			return nil
+		}
+	}
+	for _, r := range remaining {
+		if r != 0 {
+			return nil
+		}
+	}
+	return assigned
+}
@@ -0,0 +1,176 @@
+package pokernow2gw
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildPots(t *testing.T) {
+	tests := []struct {
+		name string
+		hand Hand
+		want []pot
+	}{
+		{
+			name: "no all-in is a single pot",
+			hand: Hand{
+				Players: []Player{
+					{SeatNumber: 1, DisplayName: "alice", Stack: 1000},
+					{SeatNumber: 2, DisplayName: "bob", Stack: 1000},
+				},
+				Actions: []Action{
+					{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+					{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+					{Player: "alice", ActionType: ActionCall, Amount: 20, Street: StreetPreflop},
+					{Player: "bob", ActionType: ActionBet, Amount: 40, Street: StreetFlop},
+					{Player: "alice", ActionType: ActionFold, Street: StreetFlop},
+					{Player: "bob", ActionType: ActionUncalled, Amount: 40, Street: StreetFlop},
+				},
+			},
+			want: []pot{{Amount: 40, Eligible: []string{"bob"}}},
+		},
+		{
+			name: "short all-in creates a side pot",
+			hand: Hand{
+				Players: []Player{
+					{SeatNumber: 1, DisplayName: "alice", Stack: 100},
+					{SeatNumber: 2, DisplayName: "bob", Stack: 300},
+					{SeatNumber: 3, DisplayName: "carol", Stack: 500},
+				},
+				Actions: []Action{
+					{Player: "bob", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+					{Player: "carol", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+					{Player: "alice", ActionType: ActionRaise, Amount: 100, Street: StreetPreflop, IsAllIn: true},
+					{Player: "bob", ActionType: ActionRaise, Amount: 300, Street: StreetPreflop, IsAllIn: true},
+					{Player: "carol", ActionType: ActionCall, Amount: 300, Street: StreetPreflop},
+				},
+			},
+			want: []pot{
+				{Amount: 300, Eligible: []string{"alice", "bob", "carol"}},
+				{Amount: 400, Eligible: []string{"bob", "carol"}},
+			},
+		},
+		{
+			name: "two all-ins and betting after them create two side pots",
+			hand: Hand{
+				Players: []Player{
+					{SeatNumber: 1, DisplayName: "alice", Stack: 50},
+					{SeatNumber: 2, DisplayName: "bob", Stack: 150},
+					{SeatNumber: 3, DisplayName: "carol", Stack: 1000},
+					{SeatNumber: 4, DisplayName: "dave", Stack: 1000},
+				},
+				Actions: []Action{
+					{Player: "alice", ActionType: ActionPostAnte, Amount: 0, Street: StreetPreflop},
+					{Player: "alice", ActionType: ActionCall, Amount: 50, Street: StreetPreflop, IsAllIn: true},
+					{Player: "bob", ActionType: ActionRaise, Amount: 150, Street: StreetPreflop, IsAllIn: true},
+					{Player: "carol", ActionType: ActionCall, Amount: 150, Street: StreetPreflop},
+					{Player: "dave", ActionType: ActionCall, Amount: 150, Street: StreetPreflop},
+					{Player: "carol", ActionType: ActionBet, Amount: 100, Street: StreetFlop},
+					{Player: "dave", ActionType: ActionCall, Amount: 100, Street: StreetFlop},
+				},
+			},
+			want: []pot{
+				{Amount: 200, Eligible: []string{"alice", "bob", "carol", "dave"}},
+				{Amount: 300, Eligible: []string{"bob", "carol", "dave"}},
+				{Amount: 200, Eligible: []string{"carol", "dave"}},
+			},
+		},
+		{
+			name: "folded player's chips stay in the pots they reached",
+			hand: Hand{
+				Players: []Player{
+					{SeatNumber: 1, DisplayName: "alice", Stack: 100},
+					{SeatNumber: 2, DisplayName: "bob", Stack: 1000},
+					{SeatNumber: 3, DisplayName: "carol", Stack: 1000},
+				},
+				Actions: []Action{
+					{Player: "alice", ActionType: ActionRaise, Amount: 100, Street: StreetPreflop, IsAllIn: true},
+					{Player: "bob", ActionType: ActionRaise, Amount: 300, Street: StreetPreflop},
+					{Player: "carol", ActionType: ActionCall, Amount: 300, Street: StreetPreflop},
+					{Player: "bob", ActionType: ActionBet, Amount: 200, Street: StreetFlop},
+					{Player: "carol", ActionType: ActionFold, Street: StreetFlop},
+					{Player: "bob", ActionType: ActionUncalled, Amount: 200, Street: StreetFlop},
+				},
+			},
+			want: []pot{
+				{Amount: 300, Eligible: []string{"alice", "bob"}},
+				{Amount: 400, Eligible: []string{"bob"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPots(tt.hand)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildPots() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSidePotOutput(t *testing.T) {
+	csv := `entry,at,order
+"-- ending hand #1 --",2025-11-15T05:09:14.567Z,15
+"""player1 @ id1"" collected 300 from pot",2025-11-15T05:09:14.567Z,14
+"""player3 @ id3"" collected 400 from pot",2025-11-15T05:09:14.567Z,13
+"River: 2♠, 7♦, 9♣, J♥ [3♣]",2025-11-15T05:09:14.567Z,12
+"Turn: 2♠, 7♦, 9♣ [J♥]",2025-11-15T05:09:14.567Z,11
+"Flop:  [2♠, 7♦, 9♣]",2025-11-15T05:09:14.567Z,10
+"""player3 @ id3"" shows a Q♦, Q♣.",2025-11-15T05:09:14.567Z,9
+"""player2 @ id2"" shows a J♦, 10♣.",2025-11-15T05:09:14.567Z,8
+"""player1 @ id1"" shows a A♥, A♠.",2025-11-15T05:09:14.567Z,7
+"""player3 @ id3"" calls 300",2025-11-15T05:09:14.567Z,6
+"""player2 @ id2"" raises to 300 and go all in",2025-11-15T05:09:14.567Z,5
+"""player1 @ id1"" raises to 100 and go all in",2025-11-15T05:09:14.567Z,4
+"""player3 @ id3"" posts a big blind of 20",2025-11-15T05:09:14.567Z,3
+"""player2 @ id2"" posts a small blind of 10",2025-11-15T05:09:14.567Z,2
+"Your hand is A♥, A♠",2025-11-15T05:09:14.567Z,1
+"Player stacks: #1 ""player1 @ id1"" (100) | #2 ""player2 @ id2"" (300) | #3 ""player3 @ id3"" (500)",2025-11-15T05:09:14.567Z,1
+"-- starting hand #1 (id: side1) (No Limit Texas Hold'em) (dealer: ""player1 @ id1"") --",2025-11-15T05:09:14.567Z,0`
+
+	opts := ConvertOptions{
+		HeroName:     "player1",
+		SiteName:     "PokerStars",
+		TimeLocation: time.UTC,
+		GameType:     GameTypeCash,
+	}
+
+	result, err := ParseCSV(strings.NewReader(csv), opts)
+	if err != nil {
+		t.Fatalf("ParseCSV() failed: %v", err)
+	}
+
+	assertInOrder(t, string(result.HH), []string{
+		"*** SHOW DOWN ***",
+		"player1 collected $300 from main pot\n",
+		"player3 collected $400 from side pot\n",
+		"*** SUMMARY ***",
+		"Total pot $700 Main pot $300. Side pot $400. | Rake $0\n",
+	})
+	assertPokerStarsRoundTrip(t, string(result.HH), opts)
+}
+
+func TestPotLabel(t *testing.T) {
+	tests := []struct {
+		index    int
+		potCount int
+		want     string
+	}{
+		{index: 0, potCount: 2, want: "main pot"},
+		{index: 1, potCount: 2, want: "side pot"},
+		{index: 0, potCount: 3, want: "main pot"},
+		{index: 1, potCount: 3, want: "side pot-1"},
+		{index: 2, potCount: 3, want: "side pot-2"},
+	}
+
+	for _, tt := range tests {
+		got := potLabel(tt.index, tt.potCount)
+		if got != tt.want {
+			t.Errorf("potLabel(%d, %d) = %q, want %q", tt.index, tt.potCount, got, tt.want)
+		}
+	}
+}
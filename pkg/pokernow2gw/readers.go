@@ -0,0 +1,258 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InputFormat is the name of a registered Reader
+// The built-in readers are registered under the names below; see RegisterReader for adding more.
+type InputFormat string
+
+const (
+	// InputFormatPokerNowCSV is the PokerNow CSV log (entry,at,order)
+	InputFormatPokerNowCSV InputFormat = "pokernow-csv"
+	// InputFormatOHH is a single Open Hand History JSON document (simplified or spec format)
+	InputFormatOHH InputFormat = "ohh"
+	// InputFormatOHHJSONL is Open Hand History JSON Lines, one hand per line
+	InputFormatOHHJSONL InputFormat = "ohh-jsonl"
+	// InputFormatPokerStars is PokerStars-style hand history text, as written by the pokerstars formatter
+	InputFormatPokerStars InputFormat = "pokerstars"
+)
+
+// Reader reads one input format
+type Reader interface {
+	// Sniff reports whether the input looks like this format.
+	// prefix is the start of the input; complete is true when prefix is the whole input.
+	Sniff(prefix []byte, complete bool) bool
+	// Read parses the input into hands
+	Read(r io.Reader, opts ConvertOptions) (*ReadResult, error)
+}
+
+// ReaderFuncs adapts a pair of ordinary functions to the Reader interface
+type ReaderFuncs struct {
+	SniffFunc func(prefix []byte, complete bool) bool
+	ReadFunc  func(r io.Reader, opts ConvertOptions) (*ReadResult, error)
+}
+
+// Sniff calls f.SniffFunc(prefix, complete)
+func (f ReaderFuncs) Sniff(prefix []byte, complete bool) bool {
+	return f.SniffFunc(prefix, complete)
+}
+
+// Read calls f.ReadFunc(r, opts)
+func (f ReaderFuncs) Read(r io.Reader, opts ConvertOptions) (*ReadResult, error) {
+	return f.ReadFunc(r, opts)
+}
+
+// ReadResult contains the hands read from an input
+type ReadResult struct {
+	Hands            []Hand
+	SkippedHands     int
+	SkippedHandsInfo []SkippedHandInfo
+	Ledger           *Ledger        // 入退席・バイイン履歴（対応する入力のみ）
+	SiteName         string         // 入力から分かったサイト名（ConvertOptions.SiteName が空の場合に使う）
+	TimeLocation     *time.Location // 入力から分かったタイムゾーン（ConvertOptions.TimeLocation が nil の場合に使う）
+	HeroName         string         // 入力に記録されたHero名（ConvertOptions.HeroName が空の場合に使う）
+	Diagnostics      []Diagnostic   // ハンド内で認識できなかったエントリ（PokerNow CSV 入力のみ）
+	Warnings         []Warning      // 読み込み時に情報が失われたハンド（座席の振り直し、ハンドIDのハッシュ化など）
+
+	checks map[string]handCheck // 読み込み時に検証したハンドの結果（ハンドID別）
+}
+
+var (
+	readersMu sync.RWMutex
+	readers   = map[InputFormat]Reader{
+		InputFormatPokerNowCSV: ReaderFuncs{SniffFunc: sniffPokerNowCSV, ReadFunc: readPokerNowCSV},
+		InputFormatOHH:         ReaderFuncs{SniffFunc: sniffOHH, ReadFunc: readOHH},
+		InputFormatOHHJSONL:    ReaderFuncs{SniffFunc: sniffOHHJSONL, ReadFunc: readJSONL},
+		InputFormatPokerStars:  ReaderFuncs{SniffFunc: sniffPokerStars, ReadFunc: readPokerStars},
+	}
+	// readerOrder keeps registration order so that sniffing and error messages are deterministic
+	readerOrder = []InputFormat{InputFormatPokerNowCSV, InputFormatOHH, InputFormatOHHJSONL, InputFormatPokerStars}
+)
+
+// RegisterReader makes a reader available under name
+// Registered readers take part in format detection in Parse and can be selected explicitly with
+// ConvertOptions.InputFormat. Registering a name twice, or a nil reader, panics.
+func RegisterReader(name InputFormat, reader Reader) {
+	readersMu.Lock()
+	defer readersMu.Unlock()
+
+	if reader == nil {
+		panic("pokernow2gw: RegisterReader reader is nil")
+	}
+	if _, dup := readers[name]; dup {
+		panic(fmt.Sprintf("pokernow2gw: RegisterReader called twice for %q", name))
+	}
+	readers[name] = reader
+	readerOrder = append(readerOrder, name)
+}
+
+// LookupReader returns the reader registered under name
+func LookupReader(name InputFormat) (Reader, error) {
+	readersMu.RLock()
+	reader, ok := readers[name]
+	readersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown input format %q (supported: %s)", name, joinInputFormats(Readers()))
+	}
+	return reader, nil
+}
+
+// Readers returns the names of all registered readers in registration order
+func Readers() []InputFormat {
+	readersMu.RLock()
+	defer readersMu.RUnlock()
+	return append([]InputFormat(nil), readerOrder...)
+}
+
+// detectInputFormat returns the formats whose reader accepts the start of the input
+func detectInputFormat(prefix []byte, complete bool) []InputFormat {
+	readersMu.RLock()
+	defer readersMu.RUnlock()
+
+	var candidates []InputFormat
+	for _, name := range readerOrder {
+		if readers[name].Sniff(prefix, complete) {
+			candidates = append(candidates, name)
+		}
+	}
+	return candidates
+}
+
+// selectReader picks the reader for the input: the one named in opts.InputFormat,
+// or else the only one whose Sniff accepts the input
+func selectReader(opts ConvertOptions, prefix []byte, complete bool) (Reader, error) {
+	if opts.InputFormat != "" {
+		return LookupReader(opts.InputFormat)
+	}
+
+	candidates := detectInputFormat(prefix, complete)
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("unrecognized input format (supported: %s)", joinInputFormats(Readers()))
+	case 1:
+		return LookupReader(candidates[0])
+	default:
+		return nil, fmt.Errorf("ambiguous input format: it looks like %s; set the input format explicitly", joinInputFormats(candidates))
+	}
+}
+
+// joinInputFormats returns the formats as a comma separated list
+func joinInputFormats(formats []InputFormat) string {
+	names := make([]string, 0, len(formats))
+	for _, format := range formats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, ", ")
+}
+
+// convertReadResult formats the hands of a ReadResult with the formatter selected by opts
+func convertReadResult(result *ReadResult, opts ConvertOptions) (*ConvertResult, error) {
+	// Set defaults
+	if opts.SiteName == "" {
+		opts.SiteName = result.SiteName
+		if opts.SiteName == "" {
+			opts.SiteName = "PokerStars"
+		}
+	}
+	if opts.TimeLocation == nil {
+		opts.TimeLocation = result.TimeLocation
+		if opts.TimeLocation == nil {
+			opts.TimeLocation = time.UTC
+		}
+	}
+
+	warnings := append(result.Warnings, validateHands(result, opts.StrictValidation)...)
+
+	// The hero is identified by player ID before renaming, as several players may share a nickname
+	if opts.HeroName == "" {
+		opts.HeroName = result.HeroName
+	}
+	heroKey, err := resolveHeroKey(opts.HeroName, result.Hands)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := assignPlayerNames(result.Hands, opts.PlayerNaming, opts.PlayerAliases); err != nil {
+		return nil, err
+	}
+	applyLedgerAliases(result.Ledger, opts.PlayerAliases)
+	opts.HeroName, heroKey = heroOutputName(result.Hands, heroKey, opts.HeroName)
+
+	if opts.Anonymize != "" {
+		anonymizer, err := anonymizeHands(result.Hands, result.Ledger, heroKey, opts.HeroName, opts.Anonymize, opts.AnonymizeSeed)
+		if err != nil {
+			return nil, err
+		}
+		anonymizer.anonymizeReport(result, warnings)
+	}
+	if opts.ScrubTableInfo {
+		warnings = scrubTableInfo(result.Hands, warnings, opts.AnonymizeSeed)
+	}
+
+	// Convert to the output format
+	hh, err := formatHands(result.Hands, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConvertResult{
+		HH:               hh,
+		SkippedHands:     result.SkippedHands,
+		SkippedHandsInfo: result.SkippedHandsInfo,
+		Ledger:           result.Ledger,
+		HeroName:         opts.HeroName,
+		Warnings:         warnings,
+		Diagnostics:      result.Diagnostics,
+	}, nil
+}
+
+// sniffPokerNowCSV accepts input whose first record is the PokerNow CSV header
+// The header is read as a CSV record, so it may be quoted or end with CRLF as spreadsheet exports write it.
+func sniffPokerNowCSV(prefix []byte, complete bool) bool {
+	csvReader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(prefix, []byte(utf8BOM))))
+	header, err := csvReader.Read()
+	return err == nil && isPokerNowCSVHeader(header)
+}
+
+// sniffOHH accepts a single JSON object
+// When only a prefix is available, anything starting with { whose first line is not a
+// complete JSON object (i.e., a pretty-printed or very long document) is accepted.
+func sniffOHH(prefix []byte, complete bool) bool {
+	if complete {
+		return isJSONFormat(prefix)
+	}
+	return startsWithJSONObject(prefix) && !firstLineIsJSONObject(prefix)
+}
+
+// sniffOHHJSONL accepts JSON Lines: several JSON objects, one per line
+func sniffOHHJSONL(prefix []byte, complete bool) bool {
+	if complete {
+		return isJSONLFormat(prefix)
+	}
+	return startsWithJSONObject(prefix) && firstLineIsJSONObject(prefix)
+}
+
+// startsWithJSONObject reports whether the data starts with { after leading whitespace
+func startsWithJSONObject(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+// firstLineIsJSONObject reports whether the first (complete) line of data is a JSON object on its own
+func firstLineIsJSONObject(data []byte) bool {
+	firstLine, _, found := bytes.Cut(bytes.TrimLeft(data, " \t\r\n"), []byte("\n"))
+	if !found {
+		return false
+	}
+	var temp map[string]interface{}
+	return json.Unmarshal(bytes.TrimSpace(firstLine), &temp) == nil
+}
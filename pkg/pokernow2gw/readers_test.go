@@ -0,0 +1,96 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// registerTestReader registers a reader for the duration of the test
+func registerTestReader(t *testing.T, name InputFormat, reader Reader) {
+	t.Helper()
+	RegisterReader(name, reader)
+	t.Cleanup(func() {
+		readersMu.Lock()
+		defer readersMu.Unlock()
+		delete(readers, name)
+		readerOrder = slices.DeleteFunc(readerOrder, func(n InputFormat) bool { return n == name })
+	})
+}
+
+// testHandsReader reads "#hands" files: one hand ID per line after the marker line
+var testHandsReader = ReaderFuncs{
+	SniffFunc: func(prefix []byte, complete bool) bool {
+		return bytes.HasPrefix(prefix, []byte("#hands\n"))
+	},
+	ReadFunc: func(r io.Reader, opts ConvertOptions) (*ReadResult, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		var hands []Hand
+		for _, id := range strings.Fields(strings.TrimPrefix(string(data), "#hands\n")) {
+			hands = append(hands, Hand{HandID: id, HandNumber: id})
+		}
+		return &ReadResult{Hands: hands}, nil
+	},
+}
+
+func TestRegisterReader(t *testing.T) {
+	registerTestReader(t, "test-hands", testHandsReader)
+
+	if !slices.Contains(Readers(), InputFormat("test-hands")) {
+		t.Errorf("Readers() = %v, want it to contain test-hands", Readers())
+	}
+
+	result, err := Parse(strings.NewReader("#hands\n101\n102\n"), ConvertOptions{HeroName: "hero", OutputFormat: OutputFormatOHHJSONL})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if got := strings.Count(string(result.HH), "\n"); got != 2 {
+		t.Errorf("Parse() wrote %d hands, want 2:\n%s", got, result.HH)
+	}
+
+	t.Run("duplicate name panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("RegisterReader() with a registered name did not panic")
+			}
+		}()
+		RegisterReader(InputFormatPokerNowCSV, testHandsReader)
+	})
+}
+
+func TestParse_InputFormatSelection(t *testing.T) {
+	// A reader that claims every input makes CSV input ambiguous
+	registerTestReader(t, "test-greedy", ReaderFuncs{
+		SniffFunc: func(prefix []byte, complete bool) bool { return true },
+		ReadFunc:  testHandsReader.ReadFunc,
+	})
+
+	csv := "entry,at,order\n"
+
+	_, err := Parse(strings.NewReader(csv), ConvertOptions{HeroName: "hero"})
+	if err == nil || !strings.Contains(err.Error(), "ambiguous input format") || !strings.Contains(err.Error(), "pokernow-csv, test-greedy") {
+		t.Errorf("Parse() error = %v, want an ambiguous input error listing pokernow-csv and test-greedy", err)
+	}
+
+	// Naming the format explicitly resolves the ambiguity
+	if _, err := Parse(strings.NewReader(csv), ConvertOptions{HeroName: "hero", InputFormat: InputFormatPokerNowCSV}); err != nil {
+		t.Errorf("Parse() with InputFormat failed: %v", err)
+	}
+
+	_, err = Parse(strings.NewReader(csv), ConvertOptions{HeroName: "hero", InputFormat: "xml"})
+	if err == nil || !strings.Contains(err.Error(), `unknown input format "xml"`) {
+		t.Errorf("Parse() error = %v, want unknown input format error", err)
+	}
+}
+
+func TestParse_UnrecognizedInput(t *testing.T) {
+	_, err := Parse(strings.NewReader("this is not a hand history\n"), ConvertOptions{HeroName: "hero"})
+	if err == nil || !strings.Contains(err.Error(), "unrecognized input format") || !strings.Contains(err.Error(), "pokernow-csv, ohh, ohh-jsonl, pokerstars") {
+		t.Errorf("Parse() error = %v, want an unrecognized input error listing the readers", err)
+	}
+}
@@ -8,6 +8,9 @@ import (
 // ErrSpectatorLog is returned when the log is from a spectator (no "Your hand is" entries)
 var ErrSpectatorLog = errors.New("spectator log detected: no hero cards found in any hand")
 
+// ErrHeroNotDetected is returned when no hero name is given and it cannot be inferred from the hands
+var ErrHeroNotDetected = errors.New("could not detect the hero")
+
 // GameType represents the game type (cash or tournament)
 type GameType int
 
@@ -18,6 +21,23 @@ const (
 	GameTypeCash
 )
 
+// OutputFormat is the name of a registered Formatter
+// The built-in formatters are registered under the names below; see RegisterFormatter for adding more.
+type OutputFormat string
+
+const (
+	// OutputFormatPokerStars is PokerStars-style hand history text (default)
+	OutputFormatPokerStars OutputFormat = "pokerstars"
+	// OutputFormatGGPoker is GGPoker-style hand history text
+	OutputFormatGGPoker OutputFormat = "ggpoker"
+	// OutputFormatOHH is Open Hand History JSON: one indented object per hand, separated by a blank line
+	OutputFormatOHH OutputFormat = "ohh"
+	// OutputFormatOHHJSONL is Open Hand History JSON Lines: one compact object per line
+	OutputFormatOHHJSONL OutputFormat = "ohh-jsonl"
+	// OutputFormatIPokerXML is an iPoker-style XML session document
+	OutputFormatIPokerXML OutputFormat = "ipoker"
+)
+
 // LogEntry represents a single row from the PokerNow CSV log
 type LogEntry struct {
 	Entry string    // ログ内容
@@ -27,7 +47,7 @@ type LogEntry struct {
 
 // ConvertOptions contains options for conversion
 type ConvertOptions struct {
-	HeroName          string            // 表示名（例: "whywaita"）
+	HeroName          string            // 表示名（例: "whywaita"）。空の場合はログから自動検出
 	SiteName          string            // "GTO Wizard" (default)
 	TimeLocation      *time.Location    // "UTC", "Asia/Tokyo" 等
 	TournamentName    string            // optional
@@ -36,15 +56,30 @@ type ConvertOptions struct {
 	RakePercent       float64           // Rake percentage for cash games (e.g., 5.0 for 5%)
 	RakeCapBB         float64           // Rake cap in big blinds (e.g., 4.0 for 4BB)
 	GameType          GameType          // Cash or Tournament (default: Tournament for backward compatibility)
+	OutputFormat      OutputFormat      // Name of a registered Formatter (default: PokerStars text)
+	InputFormat       InputFormat       // Name of a registered Reader (default: detected from the input)
+	PlayerNaming      PlayerNaming      // How players are named in the output (default: first display name of each player ID)
+	PlayerAliases     map[string]string // PokerNow player ID or display name → canonical name (see ReadPlayerAliases)
+	Anonymize         Anonymization     // Replace opponent names with pseudonyms (default: off)
+	AnonymizeSeed     string            // Seed for AnonymizeHash pseudonyms (required) and scrubbed hand IDs
+	ScrubTableInfo    bool              // Remove table names and replace hand IDs with seeded hashes
+	StrictValidation  bool              // Skip hands whose chips do not add up (SkipReasonChipMismatch) instead of keeping them with a warning
 }
 
 // SkipReason represents why a hand was skipped
 type SkipReason string
 
 const (
-	SkipReasonIncomplete     SkipReason = "incomplete_hand"
-	SkipReasonTooManyPlayers SkipReason = "too_many_players"
-	SkipReasonFilteredOut    SkipReason = "filtered_out"
+	SkipReasonIncomplete      SkipReason = "incomplete_hand"
+	SkipReasonTooManyPlayers  SkipReason = "too_many_players"
+	SkipReasonFilteredOut     SkipReason = "filtered_out"
+	SkipReasonUnsupportedGame SkipReason = "unsupported_game"
+	// SkipReasonChipMismatch is used for hands whose chips do not add up (see ValidateHand),
+	// usually because lines are missing from the log
+	SkipReasonChipMismatch SkipReason = "chip_mismatch"
+	// SkipReasonInvalidInput is used for JSONL lines that cannot be decoded or converted;
+	// the detail is the ParseError with the line number
+	SkipReasonInvalidInput SkipReason = "invalid_input"
 )
 
 // SkippedHandInfo contains details about a skipped hand
@@ -57,11 +92,44 @@ type SkippedHandInfo struct {
 	RawInput    []string   `json:"raw_input,omitempty"` // 元のCSVエントリ
 }
 
+// Diagnostic is a log entry inside a hand that the parser did not recognize
+// Unrecognized entries are ignored, so new PokerNow log wording shows up here rather than silently
+// changing the converted hand.
+type Diagnostic struct {
+	HandNumber string `json:"hand_number"`
+	Order      int64  `json:"order"`
+	Entry      string `json:"entry"` // 元のCSVエントリ
+}
+
+// WarningCode identifies the kind of a Warning
+type WarningCode string
+
+const (
+	// WarningChipMismatch is used for hands whose chips do not add up (they are skipped instead with StrictValidation)
+	WarningChipMismatch WarningCode = "chip_mismatch"
+	// WarningSeatsRenumbered is used for hands whose seats were renumbered from 1 to N
+	WarningSeatsRenumbered WarningCode = "seats_renumbered"
+	// WarningHandIDHashed is used for hands whose ID was not numeric and was replaced by a hash of it
+	WarningHandIDHashed WarningCode = "hand_id_hashed"
+)
+
+// Warning is a problem found in a hand that was converted anyway, or a change made to it
+// that loses information from the input
+type Warning struct {
+	Code    WarningCode `json:"code"`
+	HandID  string      `json:"hand_id"`
+	Message string      `json:"message"`
+}
+
 // ConvertResult contains the result of conversion
 type ConvertResult struct {
-	HH               []byte            // GTO Wizard HH text
+	HH               []byte            // 変換結果（OutputFormat で選んだ Formatter の出力。既定は GTO Wizard HH text）
 	SkippedHands     int               // パースに失敗したハンド数
 	SkippedHandsInfo []SkippedHandInfo // スキップされたハンドの詳細情報
+	Ledger           *Ledger           // 入退席・バイイン履歴（PokerNow CSV 入力時のみ）
+	HeroName         string            // 出力に使ったHero名（ConvertOptions.HeroName が空の場合は検出結果）
+	Warnings         []Warning         // 変換はしたが問題のあった、または情報が失われたハンド
+	Diagnostics      []Diagnostic      // ハンド内で認識できなかったログエントリ
 }
 
 // Hand represents a parsed poker hand
@@ -69,6 +137,7 @@ type Hand struct {
 	HandNumber string
 	HandID     string
 	Dealer     string
+	DealerID   string // ボタンのプレイヤーID（ID のない入力では空）
 	Players    []Player
 	Actions    []Action
 	Board      Board
@@ -77,23 +146,52 @@ type Hand struct {
 	BigBlind   float64
 	Ante       float64
 	Winners    []Winner
-	HeroCards  []string // Heroのハンド（"Your hand is" から取得）
-	TableName  string   // テーブル名（OHH format用）
-	SiteName   string   // サイト名（OHH format用）
-	Currency   string   // 通貨（OHH format用、"Chips" の場合は $ を表示しない）
+	HeroCards  []string  // Heroのハンド（"Your hand is" から取得）
+	TableName  string    // テーブル名（OHH format用）
+	SiteName   string    // サイト名（OHH format用）
+	Currency   string    // 通貨（OHH format用、"Chips" の場合は $ を表示しない）
+	Game       PokerGame // ゲーム種別（Hold'em / Omaha）
+	Limit      BetLimit  // リミット種別（No Limit / Pot Limit）
+	Level      int       // トーナメントのブラインドレベル（1始まり、0 = 不明）
 }
 
+// PokerGame represents the poker variant of a hand
+type PokerGame int
+
+const (
+	// PokerGameHoldem is Texas Hold'em (default)
+	PokerGameHoldem PokerGame = iota
+	// PokerGameOmaha is 4-card Omaha
+	PokerGameOmaha
+	// PokerGameOmaha5 is 5-card Omaha
+	PokerGameOmaha5
+	// PokerGameOmaha6 is 6-card Omaha
+	PokerGameOmaha6
+)
+
+// BetLimit represents the betting structure of a hand
+type BetLimit int
+
+const (
+	// BetLimitNoLimit is No Limit (default)
+	BetLimitNoLimit BetLimit = iota
+	// BetLimitPotLimit is Pot Limit
+	BetLimitPotLimit
+)
+
 // Player represents a player in a hand
 type Player struct {
 	SeatNumber  int
 	Name        string
-	DisplayName string // "@" で分割した左側
+	DisplayName string // "@" で分割した左側（変換時に PlayerNaming に従って出力名に置き換える）
+	ID          string // PokerNow のプレイヤーID（"@" の右側）。名前を変えても同じ。ID のない入力では空
 	Stack       float64
 }
 
 // Action represents a player action
 type Action struct {
 	Player     string
+	PlayerID   string // Player.ID と同じ（ID のない入力では空）
 	ActionType ActionType
 	Amount     float64
 	Street     Street
@@ -115,6 +213,9 @@ const (
 	ActionShow
 	ActionCollect
 	ActionUncalled
+	ActionPostStraddle // ストラドル（ライブベット）
+	ActionPostDeadSB   // 欠席中に払えなかったSB（デッドマネー）
+	ActionPostDeadBB   // 欠席中に払えなかったBB（ライブベット）
 )
 
 // Street represents the betting round
@@ -129,18 +230,29 @@ const (
 )
 
 // Board represents the community cards
+// Flop/Turn/River hold the first (or only) run of the board
 type Board struct {
 	Flop  []string // 3 cards
 	Turn  string
 	River string
+	Runs  []BoardRun // 2回目以降のラン（run it twice 等）。共有カードも含めた完全なボード
+}
+
+// BoardRun represents one additional run of the board when the hand is run more than once
+type BoardRun struct {
+	Flop  []string
+	Turn  string
+	River string
 }
 
 // Winner represents a pot winner
 type Winner struct {
 	Player    string
+	PlayerID  string // Player.ID と同じ（ID のない入力では空）
 	Amount    float64
 	HandCards []string // ショウダウンで見せたカード
 	HandName  string   // optional
+	Run       int      // 何回目のランで獲得したか（0 = 1回目）
 }
 
 // PlayerCountFilter represents the player count filter for GTO Wizard plans
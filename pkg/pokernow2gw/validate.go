@@ -0,0 +1,108 @@
+package pokernow2gw
+
+import (
+	"fmt"
+	"math"
+)
+
+// ValidateHand replays the actions of a hand and checks that its chips add up
+// Every action must be legal in the betting so far (see bettingEngine): only seated players act,
+// calls match the bet unless all-in, bets and raises are at least the minimum, an uncalled bet is
+// exactly the part nobody called, and nobody puts in more than their stack. The chips put into the
+// pot must also equal the chips collected. The returned error describes the first discrepancy found.
+func ValidateHand(hand Hand) error {
+	engine := newBettingEngine(hand)
+	for _, action := range hand.Actions {
+		if _, err := engine.apply(action); err != nil {
+			return err
+		}
+	}
+
+	contributions := playerContributions(hand)
+	pot := 0.0
+	for _, player := range hand.Players {
+		pot += contributions[player.key()]
+	}
+	collected := 0.0
+	for _, winner := range hand.Winners {
+		collected += winner.Amount
+	}
+	if pot, collected = roundAmount(pot), roundAmount(collected); math.Abs(pot-collected) > amountTolerance {
+		return fmt.Errorf("players put %s into the pot, but %s was collected", formatNumber(pot), formatNumber(collected))
+	}
+	return nil
+}
+
+// amountTolerance absorbs rounding of decimal amounts when comparing chip counts
+const amountTolerance = 0.005
+
+// streetName returns the lower case name of a street for messages
+func streetName(street Street) string {
+	switch street {
+	case StreetFlop:
+		return "flop"
+	case StreetTurn:
+		return "turn"
+	case StreetRiver:
+		return "river"
+	case StreetShowdown:
+		return "showdown"
+	default:
+		return "preflop"
+	}
+}
+
+// validateHands checks every hand with ValidateHand
+// Hands checked while they were read (see checkHand) are not validated again.
+// Inconsistent hands are kept with a warning, or skipped with SkipReasonChipMismatch when strict is set.
+func validateHands(result *ReadResult, strict bool) []Warning {
+	var warnings []Warning
+	valid := result.Hands[:0]
+	for _, hand := range result.Hands {
+		check, ok := result.checks[hand.HandID]
+		if !ok {
+			check.err = ValidateHand(hand)
+		}
+		if check.err == nil {
+			valid = append(valid, hand)
+			continue
+		}
+		detail := fmt.Sprintf("Hand #%s does not add up: %v", hand.HandNumber, check.err)
+		if !strict {
+			warnings = append(warnings, Warning{Code: WarningChipMismatch, HandID: hand.HandID, Message: detail})
+			valid = append(valid, hand)
+			continue
+		}
+		result.SkippedHands++
+		result.SkippedHandsInfo = append(result.SkippedHandsInfo, SkippedHandInfo{
+			HandID:      hand.HandID,
+			HandNumber:  hand.HandNumber,
+			Reason:      SkipReasonChipMismatch,
+			Detail:      detail,
+			PlayerCount: len(hand.Players),
+			RawInput:    check.rawInput,
+		})
+	}
+	result.Hands = valid
+	return warnings
+}
+
+// handCheck is the ValidateHand result of a hand checked while it was read
+type handCheck struct {
+	err      error    // 整合していれば nil
+	rawInput []string // 整合しないハンドの元の入力（SkipReasonChipMismatch の RawInput 用）
+}
+
+// checkHand validates a hand while its raw input is still at hand and records the result by hand ID
+// The raw input is only kept for hands that do not add up, so that streamed logs are not held in memory.
+func checkHand(checks map[string]handCheck, hand Hand, raw func() []string) map[string]handCheck {
+	if checks == nil {
+		checks = make(map[string]handCheck)
+	}
+	check := handCheck{err: ValidateHand(hand)}
+	if check.err != nil {
+		check.rawInput = raw()
+	}
+	checks[hand.HandID] = check
+	return checks
+}
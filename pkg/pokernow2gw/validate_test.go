@@ -0,0 +1,247 @@
+package pokernow2gw
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateHand(t *testing.T) {
+	players := []Player{
+		{SeatNumber: 1, DisplayName: "alice", Stack: 100},
+		{SeatNumber: 2, DisplayName: "bob", Stack: 1000},
+	}
+	blinds := []Action{
+		{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+		{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+	}
+	withBlinds := func(actions ...Action) []Action {
+		return append(append([]Action{}, blinds...), actions...)
+	}
+
+	tests := []struct {
+		name    string
+		hand    Hand
+		wantErr string
+	}{
+		{
+			name: "bet and fold with the uncalled bet returned",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "alice", ActionType: ActionCall, Amount: 20, Street: StreetPreflop},
+					Action{Player: "bob", ActionType: ActionBet, Amount: 40, Street: StreetFlop},
+					Action{Player: "alice", ActionType: ActionFold, Street: StreetFlop},
+					Action{Player: "bob", ActionType: ActionUncalled, Amount: 40, Street: StreetFlop},
+				),
+				Winners: []Winner{{Player: "bob", Amount: 40}},
+			},
+		},
+		{
+			name: "short all-in call",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "bob", ActionType: ActionRaise, Amount: 300, Street: StreetPreflop},
+					Action{Player: "alice", ActionType: ActionCall, Amount: 100, Street: StreetPreflop, IsAllIn: true},
+					Action{Player: "bob", ActionType: ActionUncalled, Amount: 200, Street: StreetPreflop},
+				),
+				Winners: []Winner{{Player: "alice", Amount: 200}},
+			},
+		},
+		{
+			name: "missing uncalled bet",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "alice", ActionType: ActionFold, Street: StreetPreflop},
+				),
+				Winners: []Winner{{Player: "bob", Amount: 20}},
+			},
+			wantErr: "players put 30 into the pot, but 20 was collected",
+		},
+		{
+			name: "wrong uncalled bet",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "alice", ActionType: ActionFold, Street: StreetPreflop},
+					Action{Player: "bob", ActionType: ActionUncalled, Amount: 20, Street: StreetPreflop},
+				),
+				Winners: []Winner{{Player: "bob", Amount: 10}},
+			},
+			wantErr: "uncalled bet of 20 is returned to bob on the preflop, but 10 of their bet was not called",
+		},
+		{
+			name: "call above the bet",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "alice", ActionType: ActionCall, Amount: 40, Street: StreetPreflop},
+				),
+				Winners: []Winner{{Player: "bob", Amount: 60}},
+			},
+			wantErr: "alice calls 40 on the preflop, but the bet is 20",
+		},
+		{
+			name: "raise that does not add chips",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "bob", ActionType: ActionRaise, Amount: 20, Street: StreetPreflop},
+				),
+			},
+			wantErr: "bob bets or raises to 20 on the preflop, but the bet is already 20",
+		},
+		{
+			name: "more than the starting stack",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "bob", ActionType: ActionRaise, Amount: 300, Street: StreetPreflop},
+					Action{Player: "alice", ActionType: ActionCall, Amount: 300, Street: StreetPreflop},
+				),
+				Winners: []Winner{{Player: "bob", Amount: 600}},
+			},
+			wantErr: "alice puts in 290 on the preflop, but only has 90 behind",
+		},
+		{
+			name: "player who is not seated",
+			hand: Hand{
+				Players: players,
+				Actions: withBlinds(
+					Action{Player: "carol", ActionType: ActionFold, Street: StreetPreflop},
+				),
+			},
+			wantErr: "carol acts but is not seated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHand(tt.hand)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateHand() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateHand() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHands(t *testing.T) {
+	newResult := func() *ReadResult {
+		return &ReadResult{Hands: []Hand{
+			{
+				HandID:     "1",
+				HandNumber: "1",
+				Players:    []Player{{DisplayName: "alice", Stack: 100}, {DisplayName: "bob", Stack: 100}},
+				Actions: []Action{
+					{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+					{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+					{Player: "alice", ActionType: ActionFold, Street: StreetPreflop},
+					{Player: "bob", ActionType: ActionUncalled, Amount: 10, Street: StreetPreflop},
+				},
+				Winners: []Winner{{Player: "bob", Amount: 20}},
+			},
+			{
+				HandID:     "2",
+				HandNumber: "2",
+				Players:    []Player{{DisplayName: "alice", Stack: 100}, {DisplayName: "bob", Stack: 100}},
+				Actions: []Action{
+					{Player: "alice", ActionType: ActionPostSB, Amount: 10, Street: StreetPreflop},
+					{Player: "bob", ActionType: ActionPostBB, Amount: 20, Street: StreetPreflop},
+					{Player: "alice", ActionType: ActionFold, Street: StreetPreflop},
+				},
+				Winners: []Winner{{Player: "bob", Amount: 20}},
+			},
+		}}
+	}
+	const detail = "Hand #2 does not add up: players put 30 into the pot, but 20 was collected"
+
+	t.Run("kept with a warning", func(t *testing.T) {
+		result := newResult()
+		warnings := validateHands(result, false)
+		want := []Warning{{Code: WarningChipMismatch, HandID: "2", Message: detail}}
+		if diff := cmp.Diff(want, warnings); diff != "" {
+			t.Errorf("Warnings mismatch (-want +got):\n%s", diff)
+		}
+		if len(result.Hands) != 2 || result.SkippedHands != 0 {
+			t.Errorf("validateHands() kept %d hands and skipped %d, want 2 and 0", len(result.Hands), result.SkippedHands)
+		}
+	})
+
+	t.Run("skipped when strict", func(t *testing.T) {
+		result := newResult()
+		if warnings := validateHands(result, true); len(warnings) != 0 {
+			t.Errorf("validateHands() warnings = %+v, want none", warnings)
+		}
+		if len(result.Hands) != 1 || result.Hands[0].HandID != "1" {
+			t.Errorf("validateHands() kept %d hands, want only hand #1", len(result.Hands))
+		}
+		want := []SkippedHandInfo{{HandID: "2", HandNumber: "2", Reason: SkipReasonChipMismatch, Detail: detail, PlayerCount: 2}}
+		if diff := cmp.Diff(want, result.SkippedHandsInfo); diff != "" || result.SkippedHands != 1 {
+			t.Errorf("SkippedHandsInfo mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("hands checked while read are not validated again", func(t *testing.T) {
+		result := newResult()
+		result.checks = map[string]handCheck{
+			"1": {err: errors.New("checked while read"), rawInput: []string{"raw hand #1"}},
+			"2": {},
+		}
+		validateHands(result, true)
+		want := []SkippedHandInfo{{HandID: "1", HandNumber: "1", Reason: SkipReasonChipMismatch, Detail: "Hand #1 does not add up: checked while read", PlayerCount: 2, RawInput: []string{"raw hand #1"}}}
+		if diff := cmp.Diff(want, result.SkippedHandsInfo); diff != "" {
+			t.Errorf("SkippedHandsInfo mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestParse_ChipMismatchRawInput(t *testing.T) {
+	// The pot of 3.5 is neither the 4 put in nor the 2 that was called
+	line := `{"ohh":{"game_type":"Holdem","game_number":"1","currency":"Chips","small_blind_amount":0.5,"big_blind_amount":1,"bet_limit":{"bet_type":"NL"},"dealer_seat":2,"hero_player_id":1,` +
+		`"players":[{"id":1,"name":"Hero","seat":1,"starting_stack":100,"cards":["Ah","Kh"]},{"id":2,"name":"Villain","seat":2,"starting_stack":100}],` +
+		`"rounds":[{"id":0,"street":"Preflop","actions":[{"action_number":1,"player_id":1,"action":"Post SB","amount":0.5},{"action_number":2,"player_id":2,"action":"Post BB","amount":1},{"action_number":3,"player_id":1,"action":"Raise","amount":3},{"action_number":4,"player_id":2,"action":"Fold"}]}],` +
+		`"pots":[{"number":0,"amount":3.5,"player_wins":[{"player_id":1,"win_amount":3.5}]}]}}`
+
+	result, err := Parse(bytes.NewReader([]byte(line+"\n")), ConvertOptions{HeroName: "Hero", InputFormat: InputFormatOHHJSONL, StrictValidation: true})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if len(result.SkippedHandsInfo) != 1 || result.SkippedHandsInfo[0].Reason != SkipReasonChipMismatch {
+		t.Fatalf("SkippedHandsInfo = %+v, want a chip mismatch", result.SkippedHandsInfo)
+	}
+	if diff := cmp.Diff([]string{line}, result.SkippedHandsInfo[0].RawInput); diff != "" {
+		t.Errorf("RawInput mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParse_SampleHandsAddUp(t *testing.T) {
+	input, err := os.ReadFile("../../sample/input/poker_now_log_pglhniqprRDmWFv9sLLZZA-ru.csv")
+	if os.IsNotExist(err) {
+		t.Skip("Sample input file not found, skipping validation test")
+	}
+	if err != nil {
+		t.Fatalf("Failed to read input file: %v", err)
+	}
+
+	result, err := Parse(bytes.NewReader(input), ConvertOptions{HeroName: "whywaita", TimeLocation: time.UTC})
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	for _, w := range result.Warnings {
+		if w.Code == WarningChipMismatch {
+			t.Errorf("hand does not add up: %s", w.Message)
+		}
+	}
+}
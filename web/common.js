@@ -113,7 +113,7 @@ function showCopySuccess() {
     }, 2000);
 }
 
-function showSuccess(message, skippedHandsInfo = null) {
+function showSuccess(message, skippedHandsInfo = null, diagnostics = null, warnings = null) {
     document.getElementById('successMessage').textContent = message;
     document.getElementById('success').style.display = 'block';
     document.getElementById('error').style.display = 'none';
@@ -124,8 +124,11 @@ function showSuccess(message, skippedHandsInfo = null) {
     const detailElement = document.getElementById('skippedDetail');
     const toggleElement = document.getElementById('skippedDetailToggle');
 
-    if (skippedHandsInfo && skippedHandsInfo.length > 0) {
-        const detailText = formatSkippedHandsInfo(skippedHandsInfo);
+    const hasSkipped = skippedHandsInfo && skippedHandsInfo.length > 0;
+    const hasDiagnostics = diagnostics && diagnostics.length > 0;
+    const hasWarnings = warnings && warnings.length > 0;
+    if (hasSkipped || hasDiagnostics || hasWarnings) {
+        const detailText = formatSkippedHandsInfo(skippedHandsInfo || [], diagnostics || [], warnings || []);
         detailElement.textContent = detailText;
         detailWrapper.style.display = 'block';
         detailContainer.style.display = 'none';
@@ -135,11 +138,14 @@ function showSuccess(message, skippedHandsInfo = null) {
     }
 }
 
-function formatSkippedHandsInfo(skippedHandsInfo) {
+function formatSkippedHandsInfo(skippedHandsInfo, diagnostics = [], warnings = []) {
     const reasonLabels = {
         'incomplete_hand': 'Incomplete hand (not properly closed)',
         'too_many_players': 'Too many players (> 10)',
-        'filtered_out': 'Filtered out by player count filter'
+        'filtered_out': 'Filtered out by player count filter',
+        'unsupported_game': 'Unsupported game',
+        'chip_mismatch': 'Chips do not add up',
+        'invalid_input': 'Invalid input line'
     };
 
     // Count by reason for summary
@@ -183,6 +189,28 @@ ${info.raw_input.join('\n')}
         text += '\n\n';
     });
 
+    if (diagnostics.length > 0) {
+        text += `=== Unrecognized Log Entries ===
+Total: ${diagnostics.length} entries
+
+`;
+        diagnostics.forEach(diagnostic => {
+            text += `Hand #${diagnostic.hand_number} (order ${diagnostic.order}): ${diagnostic.entry}\n`;
+        });
+    }
+
+    if (warnings.length > 0) {
+        text += `
+=== Warnings ===
+Total: ${warnings.length} warnings
+
+`;
+        warnings.forEach(warning => {
+            const hand = warning.hand_id ? `Hand ID ${warning.hand_id}: ` : '';
+            text += `${hand}[${warning.code}] ${warning.message}\n`;
+        });
+    }
+
     return text.trim();
 }
 
@@ -251,6 +279,12 @@ function extractDayPlayed(hhOutput) {
     return now.toISOString().slice(0, 10) + '_' + now.toISOString().slice(11, 19).replace(/:/g, '-');
 }
 
+// Returns the hero name from the "Dealt to" line, for outputs converted with a detected hero
+function extractHeroName(hhOutput) {
+    const heroMatch = hhOutput.match(/^Dealt to (.+?) \[/m);
+    return heroMatch ? heroMatch[1] : 'hero';
+}
+
 function downloadFile(content, filename) {
     const blob = new Blob([content], { type: 'text/plain' });
     const url = URL.createObjectURL(blob);
@@ -263,9 +297,13 @@ function downloadFile(content, filename) {
     URL.revokeObjectURL(url);
 }
 
-function callWasmParseCSV(csvInput, heroName, filterFlags, gameType, rakePercent, rakeCapBB) {
+// outputFormat is the name of a registered formatter (e.g. "pokerstars", "ggpoker", "ohh");
+// omit it for the default PokerStars output
+function callWasmParseCSV(csvInput, heroName, filterFlags, gameType, rakePercent, rakeCapBB, outputFormat) {
     const csvData = allocateString(csvInput);
-    const heroData = allocateString(heroName);
+    // An empty hero name is detected from the log by the converter
+    const heroData = heroName ? allocateString(heroName) : { ptr: 0, length: 0 };
+    const formatData = outputFormat ? allocateString(outputFormat) : { ptr: 0, length: 0 };
 
     let resultInfoPtr;
     if (rakePercent !== undefined && rakeCapBB !== undefined) {
@@ -276,7 +314,8 @@ function callWasmParseCSV(csvInput, heroName, filterFlags, gameType, rakePercent
             filterFlags,
             gameType,
             rakePercent,
-            rakeCapBB
+            rakeCapBB,
+            formatData.ptr, formatData.length
         );
     } else {
         // Tournament mode: pass 0 for rake parameters
@@ -286,7 +325,8 @@ function callWasmParseCSV(csvInput, heroName, filterFlags, gameType, rakePercent
             filterFlags,
             gameType,
             0,  // rakePercent
-            0   // rakeCapBB
+            0,  // rakeCapBB
+            formatData.ptr, formatData.length
         );
     }
 
@@ -304,19 +344,30 @@ function callWasmParseCSV(csvInput, heroName, filterFlags, gameType, rakePercent
 
     // Read skipped hands detail JSON if available
     let skippedHandsInfo = null;
+    let diagnostics = null;
+    let warnings = null;
     if (skippedDetailPtr > 0 && skippedDetailLen > 0) {
         const skippedDetailJson = readString(skippedDetailPtr, skippedDetailLen);
         try {
-            skippedHandsInfo = JSON.parse(skippedDetailJson);
+            const skippedDetail = JSON.parse(skippedDetailJson);
+            if (Array.isArray(skippedDetail)) {
+                // Version 1 (an older cached pokernow2gw.wasm): a bare array of skipped hands
+                skippedHandsInfo = skippedDetail;
+            } else {
+                // Version 2: { version, skipped_hands, diagnostics, warnings }
+                skippedHandsInfo = skippedDetail.skipped_hands;
+                diagnostics = skippedDetail.diagnostics;
+                warnings = skippedDetail.warnings;
+            }
         } catch (e) {
             console.error("Failed to parse skipped hands info:", e);
         }
     }
 
-    return { resultText, skippedHands, skippedHandsInfo };
+    return { resultText, skippedHands, skippedHandsInfo, diagnostics, warnings };
 }
 
-function handleConversionResult(resultText, skippedHands, skippedHandsInfo, errorDetail) {
+function handleConversionResult(resultText, skippedHands, skippedHandsInfo, errorDetail, diagnostics = null, warnings = null) {
     // Hide loading
     document.getElementById('loading').style.display = 'none';
     document.getElementById('convertBtn').disabled = false;
@@ -335,7 +386,13 @@ function handleConversionResult(resultText, skippedHands, skippedHandsInfo, erro
     if (skippedHands > 0) {
         message += ` (${skippedHands} hands were skipped)`;
     }
-    showSuccess(message, skippedHandsInfo);
+    if (diagnostics && diagnostics.length > 0) {
+        message += ` (${diagnostics.length} log entries were not recognized)`;
+    }
+    if (warnings && warnings.length > 0) {
+        message += ` (${warnings.length} warnings)`;
+    }
+    showSuccess(message, skippedHandsInfo, diagnostics, warnings);
 
     // Scroll to result
     document.getElementById('resultContainer').scrollIntoView({ behavior: 'smooth' });